@@ -0,0 +1,387 @@
+package munkres
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+)
+
+//DuplicateRows groups the indices of rows whose elements all agree within tol.
+//Only groups with at least two rows are returned, each in ascending order.
+func DuplicateRows(m *FloatMatrix, tol float64) [][]int64 {
+	n := m.N
+	grouped := make([]bool, n)
+	var groups [][]int64
+	for i := zero64; i < n; i++ {
+		if grouped[i] {
+			continue
+		}
+		group := []int64{i}
+		for k := i + 1; k < n; k++ {
+			if !grouped[k] && rowsEqual(m, i, k, tol) {
+				grouped[k] = true
+				group = append(group, k)
+			}
+		}
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func rowsEqual(m *FloatMatrix, a, b int64, tol float64) bool {
+	for j := zero64; j < m.N; j++ {
+		x, y := m.GetElement(a, j), m.GetElement(b, j)
+		if x != y && !(math.Abs(x-y) <= tol) {
+			return false
+		}
+	}
+	return true
+}
+
+//FindIndependentBlocks returns the connected components of the bipartite graph whose edges are the allowed cells of m,
+//that is those below forbiddenSentinel and not +Inf. Rows are numbered 0 to N-1 and columns N to 2N-1, so each block
+//lists its rows followed by its columns in ascending order. Blocks are ordered by their first index.
+func FindIndependentBlocks(m *FloatMatrix, forbiddenSentinel float64) [][]int64 {
+	n := m.N
+	parent := make([]int64, 2*n)
+	for v := range parent {
+		parent[v] = int64(v)
+	}
+	var find func(v int64) int64
+	find = func(v int64) int64 {
+		if parent[v] != v {
+			parent[v] = find(parent[v])
+		}
+		return parent[v]
+	}
+	for i := zero64; i < n; i++ {
+		for j := zero64; j < n; j++ {
+			v := m.GetElement(i, j)
+			if v < forbiddenSentinel && !math.IsInf(v, 1) {
+				a, b := find(i), find(n+j)
+				if a != b {
+					parent[b] = a
+				}
+			}
+		}
+	}
+	blockOf := make(map[int64]int)
+	var blocks [][]int64
+	for v := zero64; v < 2*n; v++ {
+		root := find(v)
+		idx, ok := blockOf[root]
+		if !ok {
+			idx = len(blocks)
+			blockOf[root] = idx
+			blocks = append(blocks, nil)
+		}
+		blocks[idx] = append(blocks[idx], v)
+	}
+	return blocks
+}
+
+//DiffAssignments solves a and b and reports the (row, col) pairs that entered the optimal assignment of b
+//and those that left the optimal assignment of a. Both matrices must have the same size.
+func DiffAssignments(a, b *FloatMatrix) (added, removed [][2]int64, err error) {
+	if a.N != b.N {
+		return nil, nil, fmt.Errorf("munkres: matrices of size %d and %d: %w", a.N, b.N, ErrDimensionMismatch)
+	}
+	before, _, err := Solve(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	after, _, err := Solve(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range before {
+		if before[i].Col != after[i].Col {
+			removed = append(removed, [2]int64{before[i].Row, before[i].Col})
+			added = append(added, [2]int64{after[i].Row, after[i].Col})
+		}
+	}
+	return added, removed, nil
+}
+
+//AssignmentRegret solves m and returns, for each row, its assigned cost minus the cheapest cost among the row's other
+//columns. Positive values mark rows that gave up a cheaper column for the sake of the total. A 1x1 matrix has no
+//alternatives and reports 0; nil is returned if m cannot be solved.
+func AssignmentRegret(m *FloatMatrix) []float64 {
+	result, _, err := Solve(m)
+	if err != nil {
+		return nil
+	}
+	regret := make([]float64, m.N)
+	if m.N < 2 {
+		return regret
+	}
+	for _, a := range result {
+		alt := math.Inf(1)
+		for j := zero64; j < m.N; j++ {
+			if j != a.Col {
+				alt = math.Min(alt, m.GetElement(a.Row, j))
+			}
+		}
+		regret[a.Row] = a.Cost - alt
+	}
+	return regret
+}
+
+//RowColSlack solves m and reads the slack of every row and column off the final reduced matrix, whose cells hold each
+//cost minus its row and column potentials and are zero on the assignment. rowSlack[i] is the smallest reduced cost
+//among row i's unassigned cells: row i's potential can rise by that much, or any other column of the row become that
+//much cheaper, before the row has a second tight column and the assignment may change. colSlack is the same for columns.
+//Rows or columns with no finite alternative report +Inf; nil slices are returned if m cannot be solved.
+func RowColSlack(m *FloatMatrix) (rowSlack, colSlack []float64) {
+	if m.Validate() != nil {
+		return nil, nil
+	}
+	ctx := newContext(m)
+	if ctx.run() != nil {
+		return nil, nil
+	}
+	n := m.N
+	rowSlack = make([]float64, n)
+	colSlack = make([]float64, n)
+	for k := range rowSlack {
+		rowSlack[k] = math.Inf(1)
+		colSlack[k] = math.Inf(1)
+	}
+	for pos, markedVal := range ctx.marked {
+		if markedVal == Starred {
+			continue
+		}
+		i, j := int64(pos)/n, int64(pos)%n
+		r := math.Max(ctx.m.A[pos], 0)
+		rowSlack[i] = math.Min(rowSlack[i], r)
+		colSlack[j] = math.Min(colSlack[j], r)
+	}
+	return rowSlack, colSlack
+}
+
+//maxCountSize is the largest matrix CountOptimalAssignments accepts; its subset DP needs 2^N counters
+const maxCountSize = 20
+
+//CountOptimalAssignments returns how many distinct assignments achieve the minimum total cost. It counts the perfect
+//matchings among the zero cells of the final reduced matrix, which are exactly the optimal assignments. Counting
+//matchings is #P-hard, so matrices larger than 20x20 are rejected.
+func CountOptimalAssignments(m *FloatMatrix) (int, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+	if m.N > maxCountSize {
+		return 0, fmt.Errorf("munkres: counting optimal assignments is limited to %dx%d matrices, got %dx%d",
+			maxCountSize, maxCountSize, m.N, m.N)
+	}
+	ctx := newContext(m)
+	if err := ctx.run(); err != nil {
+		return 0, err
+	}
+	n := m.N
+	tol := zeroTolerance(m)
+	//ways[mask] counts the matchings of the first popcount(mask) rows onto exactly the columns in mask
+	ways := make([]int, 1<<uint(n))
+	ways[0] = 1
+	for mask := range ways {
+		if ways[mask] == 0 {
+			continue
+		}
+		i := int64(bits.OnesCount(uint(mask)))
+		if i == n {
+			continue
+		}
+		for j := zero64; j < n; j++ {
+			if mask&(1<<uint(j)) == 0 && ctx.m.A[i*n+j] <= tol {
+				ways[mask|1<<uint(j)] += ways[mask]
+			}
+		}
+	}
+	return ways[len(ways)-1], nil
+}
+
+//CostHistogram counts the finite elements of m in buckets of equal width spanning the smallest to the largest
+//finite element; the largest lands in the last bucket. Forbidden (+Inf) cells, -Inf cells and NaNs are not counted,
+//nor do they affect the bucket bounds. It returns nil if buckets is not positive.
+func CostHistogram(m *FloatMatrix, buckets int) []int {
+	if buckets <= 0 {
+		return nil
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range m.A {
+		if !math.IsInf(v, 0) && !math.IsNaN(v) {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	counts := make([]int, buckets)
+	width := (hi - lo) / float64(buckets)
+	for _, v := range m.A {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			continue
+		}
+		b := 0
+		if width > 0 {
+			b = int((v - lo) / width)
+		}
+		if b >= buckets {
+			b = buckets - 1
+		}
+		counts[b]++
+	}
+	return counts
+}
+
+//AssignmentStability estimates how robust the optimal assignment of m is. Each of trials perturbs every finite cell by
+//uniform noise in [-noise, noise] drawn from rng and re-solves; the result is the fraction of row to column pairs,
+//across all trials, that match the unperturbed optimum. It returns 1 when there is nothing to measure and 0 if m
+//cannot be solved.
+func AssignmentStability(m *FloatMatrix, noise float64, trials int, rng *rand.Rand) float64 {
+	base, _, err := Solve(m)
+	if err != nil {
+		return 0
+	}
+	if trials <= 0 || m.N == 0 {
+		return 1
+	}
+	perturbed := NewMatrix(m.N)
+	kept := 0
+	for t := 0; t < trials; t++ {
+		for idx, v := range m.A {
+			if !math.IsInf(v, 1) {
+				v += (2*rng.Float64() - 1) * noise
+			}
+			perturbed.A[idx] = v
+		}
+		perm, err := solvePerm(perturbed)
+		if err != nil {
+			continue
+		}
+		for i, j := range perm {
+			if base[i].Col == j {
+				kept++
+			}
+		}
+	}
+	return float64(kept) / float64(int64(trials)*m.N)
+}
+
+//MinimumLineCover solves m and returns the rows and columns covered when the algorithm stops. These lines cover every
+//zero of the final reduced matrix using as few lines as possible, a minimum vertex cover of the zero graph whose size
+//equals its maximum matching by Konig's theorem. At optimality that matching is complete, so the cover is trivial:
+//step 3 stops once it has covered the N columns holding a starred zero, which means rows is empty and cols lists every
+//column. It returns nil slices if m cannot be solved.
+func MinimumLineCover(m *FloatMatrix) (rows, cols []int64) {
+	if m.Validate() != nil {
+		return nil, nil
+	}
+	ctx := newContext(m)
+	if ctx.run() != nil {
+		return nil, nil
+	}
+	for k := zero64; k < m.N; k++ {
+		if ctx.rowCovered[k] {
+			rows = append(rows, k)
+		}
+		if ctx.colCovered[k] {
+			cols = append(cols, k)
+		}
+	}
+	return rows, cols
+}
+
+//MarginalValueOfColumn reports how much the optimal total of m falls when a column with costs newCol is added, as when
+//asking what one more worker would save. The grown matrix gets a dummy row of zero costs that takes whichever column
+//ends up unused, so the result is never negative and is zero when the new column does not help. newCol must hold N
+//values.
+func MarginalValueOfColumn(m *FloatMatrix, newCol []float64) (float64, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+	grown, err := m.AppendRowCol(make([]float64, m.N), newCol, 0)
+	if err != nil {
+		return 0, err
+	}
+	if err := grown.Validate(); err != nil {
+		return 0, err
+	}
+	_, before, err := Solve(m)
+	if err != nil {
+		return 0, err
+	}
+	_, after, err := Solve(grown)
+	if err != nil {
+		return 0, err
+	}
+	return math.Max(before-after, 0), nil
+}
+
+//IsPairOptimal reports whether the pair (i,j) belongs to at least one optimal assignment of m. It solves m once as is
+//and once with the rest of row i and column j forbidden, forcing the pair, and compares the two totals; totals within
+//N times zeroTolerance count as equal so that rounding in the sums does not hide a tie.
+func IsPairOptimal(m *FloatMatrix, i, j int64) (bool, error) {
+	if err := m.Validate(); err != nil {
+		return false, err
+	}
+	if i < 0 || j < 0 || i >= m.N || j >= m.N {
+		return false, fmt.Errorf("munkres: pair (%d,%d) of a matrix of size %d: %w", i, j, m.N, ErrOutOfRange)
+	}
+	_, best, err := Solve(m)
+	if err != nil {
+		return false, err
+	}
+	forced := NewMatrix(m.N)
+	copy(forced.A, m.A)
+	for k := zero64; k < m.N; k++ {
+		if k != j {
+			forced.SetElement(i, k, math.Inf(1))
+		}
+		if k != i {
+			forced.SetElement(k, j, math.Inf(1))
+		}
+	}
+	_, total, err := Solve(forced)
+	if err == ErrInfeasible {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return total-best <= float64(m.N)*zeroTolerance(m), nil
+}
+
+//BothResult holds the solutions of a matrix and of its transpose found by SolveBoth
+type BothResult struct {
+	//Forward is the assignment of m and ForwardTotal its total
+	Forward      []Assignment
+	ForwardTotal float64
+	//Transposed is the assignment of the transpose, whose rows are the columns of m, and TransposedTotal its total
+	Transposed      []Assignment
+	TransposedTotal float64
+}
+
+//SolveBoth solves m and its transpose independently. Both problems choose among the same sets of cells, so the totals
+//must agree up to rounding; a disagreement points to a bug or to costs so ill-conditioned that the solver cannot be
+//trusted with them.
+func SolveBoth(m *FloatMatrix) (BothResult, error) {
+	if err := m.Validate(); err != nil {
+		return BothResult{}, err
+	}
+	t := NewMatrix(m.N)
+	for i := zero64; i < m.N; i++ {
+		for j := zero64; j < m.N; j++ {
+			t.SetElement(j, i, m.GetElement(i, j))
+		}
+	}
+	var res BothResult
+	var err error
+	if res.Forward, res.ForwardTotal, err = Solve(m); err != nil {
+		return BothResult{}, err
+	}
+	if res.Transposed, res.TransposedTotal, err = Solve(t); err != nil {
+		return BothResult{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,370 @@
+package munkres
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//equalInts reports whether a and b hold the same values
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDuplicateRows(t *testing.T) {
+	m := &FloatMatrix{N: 4, A: []float64{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		1, 2, 3, 4.05,
+		5, 6, 7, 8,
+	}}
+	if got := fmt.Sprint(DuplicateRows(m, 0)); got != "[[1 3]]" {
+		t.Fatalf("DuplicateRows(m, 0) = %v", got)
+	}
+	if got := fmt.Sprint(DuplicateRows(m, 0.1)); got != "[[0 2] [1 3]]" {
+		t.Fatalf("DuplicateRows(m, 0.1) = %v", got)
+	}
+	m.A[3], m.A[11] = math.Inf(1), math.Inf(1)
+	if got := fmt.Sprint(DuplicateRows(m, 0.1)); got != "[[0 2] [1 3]]" {
+		t.Fatalf("forbidden cells in both rows: DuplicateRows = %v", got)
+	}
+}
+
+func TestFindIndependentBlocks(t *testing.T) {
+	inf := math.Inf(1)
+	m := &FloatMatrix{N: 4, A: []float64{
+		1, 2, inf, inf,
+		3, 4, inf, inf,
+		inf, inf, 5, 6,
+		inf, inf, 7, 8,
+	}}
+	if got := fmt.Sprint(FindIndependentBlocks(m, inf)); got != "[[0 1 4 5] [2 3 6 7]]" {
+		t.Fatalf("FindIndependentBlocks = %v", got)
+	}
+	//with a sentinel of 5 only cell (2,3) of the second block stays allowed, leaving row 3 and column 2 on their own
+	m.A[11] = 1
+	if got := fmt.Sprint(FindIndependentBlocks(m, 5)); got != "[[0 1 4 5] [2 7] [3] [6]]" {
+		t.Fatalf("FindIndependentBlocks with sentinel 5 = %v", got)
+	}
+}
+
+func TestDiffAssignments(t *testing.T) {
+	a := &FloatMatrix{N: 3, A: []float64{
+		0, 5, 9,
+		5, 0, 9,
+		9, 9, 0,
+	}}
+	b := &FloatMatrix{N: 3, A: append([]float64(nil), a.A...)}
+	//making (1,1) expensive swaps the columns of rows 0 and 1 and leaves row 2 alone
+	b.SetElement(1, 1, 20)
+	added, removed, err := DiffAssignments(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(added) != "[[0 1] [1 0]]" || fmt.Sprint(removed) != "[[0 0] [1 1]]" {
+		t.Fatalf("added %v, removed %v", added, removed)
+	}
+	if added, removed, _ := DiffAssignments(a, a); added != nil || removed != nil {
+		t.Fatalf("identical matrices: added %v, removed %v", added, removed)
+	}
+	if _, _, err := DiffAssignments(a, NewMatrix(2)); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("different sizes: err = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestAssignmentRegret(t *testing.T) {
+	//the optimum takes (0,1), (1,0) and (2,2); row 1 gives up its zero for the sake of the total
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	if got, want := AssignmentRegret(m), []float64{-2, 2, 0}; !equalFloats(got, want) {
+		t.Fatalf("AssignmentRegret = %v, want %v", got, want)
+	}
+	if got := AssignmentRegret(&FloatMatrix{N: 1, A: []float64{7}}); !equalFloats(got, []float64{0}) {
+		t.Fatalf("1x1 matrix: AssignmentRegret = %v, want [0]", got)
+	}
+	if got := AssignmentRegret(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}); got != nil {
+		t.Fatalf("infeasible matrix: AssignmentRegret = %v, want nil", got)
+	}
+}
+
+func TestRowColSlack(t *testing.T) {
+	//row reduction leaves [0 3 5; 2 0 7; 3 5 0], already optimal on the diagonal
+	m := &FloatMatrix{N: 3, A: []float64{1, 4, 6, 3, 1, 8, 5, 7, 2}}
+	rowSlack, colSlack := RowColSlack(m)
+	if want := []float64{3, 2, 3}; !equalFloats(rowSlack, want) {
+		t.Fatalf("rowSlack = %v, want %v", rowSlack, want)
+	}
+	if want := []float64{2, 3, 5}; !equalFloats(colSlack, want) {
+		t.Fatalf("colSlack = %v, want %v", colSlack, want)
+	}
+	//a forbidden cell is no alternative, so row 0 keeps its other off-diagonal cell and column 2 loses all but one
+	m.SetElement(0, 1, math.Inf(1))
+	m.SetElement(1, 2, math.Inf(1))
+	rowSlack, colSlack = RowColSlack(m)
+	if want := []float64{5, 2, 3}; !equalFloats(rowSlack, want) {
+		t.Fatalf("with forbidden cells: rowSlack = %v, want %v", rowSlack, want)
+	}
+	if want := []float64{2, 5, 5}; !equalFloats(colSlack, want) {
+		t.Fatalf("with forbidden cells: colSlack = %v, want %v", colSlack, want)
+	}
+	rowSlack, colSlack = RowColSlack(&FloatMatrix{N: 1, A: []float64{5}})
+	if !math.IsInf(rowSlack[0], 1) || !math.IsInf(colSlack[0], 1) {
+		t.Fatalf("1x1 matrix: %v, %v, want +Inf", rowSlack, colSlack)
+	}
+}
+
+func TestCountOptimalAssignments(t *testing.T) {
+	for _, c := range []struct {
+		m    *FloatMatrix
+		want int
+	}{
+		{NewMatrix(3), 6},
+		{&FloatMatrix{N: 3, A: []float64{0, 1, 1, 1, 0, 1, 1, 1, 0}}, 1},
+		{&FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}, 1},
+		{&FloatMatrix{N: 2, A: []float64{1, 2, 3, 4}}, 2},
+		{&FloatMatrix{N: 2, A: []float64{1, 2, math.Inf(1), 4}}, 1},
+	} {
+		got, err := CountOptimalAssignments(c.m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("%v: %d optimal assignments, want %d", c.m.A, got, c.want)
+		}
+	}
+	if _, err := CountOptimalAssignments(NewMatrix(maxCountSize + 1)); err == nil {
+		t.Fatal("oversized matrix accepted")
+	}
+}
+
+func TestCountOptimalAssignmentsMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(23))
+	for trial := 0; trial < 50; trial++ {
+		n := int64(1 + r.Intn(5))
+		//few distinct costs make ties between optima common
+		m := NewMatrix(n)
+		for idx := range m.A {
+			m.A[idx] = float64(r.Intn(3))
+		}
+		best := bruteForceMin(m)
+		want := 0
+		perm := make([]int64, n)
+		for i := range perm {
+			perm[i] = int64(i)
+		}
+		var permute func(k int)
+		permute = func(k int) {
+			if k == len(perm) {
+				if total, _ := m.PermutationCost(perm); total == best {
+					want++
+				}
+				return
+			}
+			for x := k; x < len(perm); x++ {
+				perm[k], perm[x] = perm[x], perm[k]
+				permute(k + 1)
+				perm[k], perm[x] = perm[x], perm[k]
+			}
+		}
+		permute(0)
+		if got, err := CountOptimalAssignments(m); err != nil || got != want {
+			t.Fatalf("trial %d: %d optimal assignments (%v), want %d", trial, got, err, want)
+		}
+	}
+}
+
+func TestCostHistogram(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8}}
+	if got, want := CostHistogram(m, 4), []int{2, 2, 2, 3}; !equalInts(got, want) {
+		t.Fatalf("CostHistogram = %v, want %v", got, want)
+	}
+	if CostHistogram(m, 0) != nil {
+		t.Fatal("zero buckets did not return nil")
+	}
+}
+
+func TestCostHistogramSkipsNaNAndInfinities(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{math.NaN(), 1, 2, 3}}
+	if got, want := CostHistogram(m, 2), []int{1, 2}; !equalInts(got, want) {
+		t.Fatalf("CostHistogram with a NaN = %v, want %v", got, want)
+	}
+	m.A[0] = math.Inf(-1)
+	m.A[3] = math.Inf(1)
+	if got, want := CostHistogram(m, 2), []int{1, 1}; !equalInts(got, want) {
+		t.Fatalf("CostHistogram with infinities = %v, want %v", got, want)
+	}
+}
+
+func TestAssignmentStability(t *testing.T) {
+	rng := rand.New(rand.NewSource(31))
+	m := randomMatrix(rng, 6)
+	if got := AssignmentStability(m, 0, 10, rng); got != 1 {
+		t.Fatalf("zero noise: stability %v, want 1", got)
+	}
+	separated := NewMatrix(4)
+	for idx := range separated.A {
+		separated.A[idx] = 100
+	}
+	separated.SetDiagonal([]float64{0, 0, 0, 0})
+	if got := AssignmentStability(separated, 1, 20, rng); got != 1 {
+		t.Fatalf("noise far below the cost gaps: stability %v, want 1", got)
+	}
+	//every assignment of a uniform matrix is optimal, so any noise reshuffles it
+	if got := AssignmentStability(NewMatrix(4), 1, 20, rng); got >= 1 || got < 0 {
+		t.Fatalf("uniform matrix: stability %v, want below 1", got)
+	}
+	if got := AssignmentStability(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}, 1, 5, rng); got != 0 {
+		t.Fatalf("infeasible matrix: stability %v, want 0", got)
+	}
+}
+
+func TestMinimumLineCover(t *testing.T) {
+	r := rand.New(rand.NewSource(34))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(8)))
+		rows, cols := MinimumLineCover(m)
+		//at optimality the zero graph has a complete matching, so by Konig's theorem its smallest cover has N lines,
+		//and the solve ends with step 3 covering the column of every starred zero
+		if len(rows) != 0 || int64(len(cols)) != m.N {
+			t.Fatalf("trial %d: %d rows and %d columns cover a %dx%d matrix", trial, len(rows), len(cols), m.N, m.N)
+		}
+		for k, j := range cols {
+			if j != int64(k) {
+				t.Fatalf("trial %d: covered columns %v, want every column in order", trial, cols)
+			}
+		}
+		covered := make(map[[2]int64]bool)
+		for _, i := range rows {
+			for j := int64(0); j < m.N; j++ {
+				covered[[2]int64{i, j}] = true
+			}
+		}
+		for _, j := range cols {
+			for i := int64(0); i < m.N; i++ {
+				covered[[2]int64{i, j}] = true
+			}
+		}
+		ctx := solvedContext(t, m)
+		for idx, v := range ctx.m.A {
+			if i, j := int64(idx)/m.N, int64(idx)%m.N; v == 0 && !covered[[2]int64{i, j}] {
+				t.Fatalf("trial %d: zero at (%d,%d) is not covered", trial, i, j)
+			}
+		}
+	}
+	if rows, cols := MinimumLineCover(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}); rows != nil || cols != nil {
+		t.Fatalf("infeasible matrix gave %v, %v", rows, cols)
+	}
+}
+
+func TestMarginalValueOfColumn(t *testing.T) {
+	//the optimum is 4+4; a new column costing 1 for row 0 lets row 0 move there for a total of 1+4
+	m := &FloatMatrix{N: 2, A: []float64{4, 6, 6, 4}}
+	if got, err := MarginalValueOfColumn(m, []float64{1, 9}); err != nil || got != 3 {
+		t.Fatalf("cheap column: %v, %v, want 3", got, err)
+	}
+	if got, err := MarginalValueOfColumn(m, []float64{10, 10}); err != nil || got != 0 {
+		t.Fatalf("useless column: %v, %v, want 0", got, err)
+	}
+	if _, err := MarginalValueOfColumn(m, []float64{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("short column: err = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestIsPairOptimal(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{
+		1, 5, 5,
+		5, 1, 5,
+		5, 5, math.Inf(1),
+	}}
+	for _, c := range []struct {
+		i, j int64
+		want bool
+	}{{0, 0, true}, {1, 1, true}, {0, 1, false}, {2, 2, false}} {
+		got, err := IsPairOptimal(m, c.i, c.j)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("IsPairOptimal(%d, %d) = %v, want %v", c.i, c.j, got, c.want)
+		}
+	}
+	if _, err := IsPairOptimal(m, 3, 0); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("pair outside the matrix: err = %v, want ErrOutOfRange", err)
+	}
+
+	r := rand.New(rand.NewSource(194))
+	for trial := 0; trial < 20; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(5)))
+		for idx := range m.A {
+			m.A[idx] = float64(int(m.A[idx]) % 4)
+		}
+		best := bruteForceMin(m)
+		for i := int64(0); i < m.N; i++ {
+			for j := int64(0); j < m.N; j++ {
+				rest := NewMatrix(m.N - 1)
+				for a, ra := int64(0), int64(0); a < m.N; a++ {
+					if a == i {
+						continue
+					}
+					for b, cb := int64(0), int64(0); b < m.N; b++ {
+						if b == j {
+							continue
+						}
+						rest.SetElement(ra, cb, m.GetElement(a, b))
+						cb++
+					}
+					ra++
+				}
+				want := m.GetElement(i, j)+bruteForceMin(rest) == best
+				got, err := IsPairOptimal(m, i, j)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Fatalf("trial %d: IsPairOptimal(%d, %d) = %v, want %v", trial, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveBoth(t *testing.T) {
+	r := rand.New(rand.NewSource(199))
+	for trial := 0; trial < 40; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(7)))
+		if m.N > 1 {
+			m.SetElement(0, 1, math.Inf(1))
+		}
+		res, err := SolveBoth(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAssignment(t, m, res.Forward, res.ForwardTotal)
+		transposed := NewMatrix(m.N)
+		for i := int64(0); i < m.N; i++ {
+			for j := int64(0); j < m.N; j++ {
+				transposed.SetElement(j, i, m.GetElement(i, j))
+			}
+		}
+		checkAssignment(t, transposed, res.Transposed, res.TransposedTotal)
+		if res.ForwardTotal != res.TransposedTotal {
+			t.Fatalf("trial %d: forward total %v, transposed total %v", trial, res.ForwardTotal, res.TransposedTotal)
+		}
+		if want := bruteForceMin(m); res.ForwardTotal != want {
+			t.Fatalf("trial %d: totals %v, want %v", trial, res.ForwardTotal, want)
+		}
+	}
+	m := &FloatMatrix{N: 2, A: []float64{math.Inf(1), 1, math.Inf(1), 2}}
+	if _, err := SolveBoth(m); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("infeasible matrix: err = %v, want ErrInfeasible", err)
+	}
+}
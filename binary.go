@@ -0,0 +1,120 @@
+package munkres
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+//binaryMagic opens every matrix in the binary format. It is followed by N as a little-endian int64 and then the N*N
+//elements row by row as little-endian float64 bit patterns, so the elements start 8-byte aligned at offset 16.
+const binaryMagic = "MUNKRES1"
+
+const binaryHeaderSize = len(binaryMagic) + 8
+
+//maxBinarySize is the largest N accepted in a header, the largest for which the header and 8*N*N bytes of elements
+//still fit in an int64
+const maxBinarySize = 1<<30 - 1
+
+//binaryPrealloc bounds the elements reserved before any are read, so that a header announcing a huge N cannot make a
+//short stream allocate for data it does not hold; the slice grows as elements actually arrive
+const binaryPrealloc = 1 << 16
+
+//ErrBadBinaryHeader is returned when data does not start with a valid binary matrix header
+var ErrBadBinaryHeader = errors.New("munkres: bad binary matrix header")
+
+//WriteBinary writes m to w in the package's binary format
+func WriteBinary(w io.Writer, m *FloatMatrix) error {
+	if !m.isSquare() {
+		return ErrNotSquare
+	}
+	bw := bufio.NewWriter(w)
+	var buf [8]byte
+	bw.WriteString(binaryMagic)
+	binary.LittleEndian.PutUint64(buf[:], uint64(m.N))
+	bw.Write(buf[:])
+	for _, v := range m.A {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		bw.Write(buf[:])
+	}
+	return bw.Flush()
+}
+
+//ReadBinary reads a matrix written by WriteBinary
+func ReadBinary(r io.Reader) (*FloatMatrix, error) {
+	return readBinary(bufio.NewReader(r))
+}
+
+//readBinary reads one binary matrix from br, leaving anything after its last element unread
+func readBinary(br *bufio.Reader) (*FloatMatrix, error) {
+	header := make([]byte, binaryHeaderSize)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadBinaryHeader, err)
+	}
+	n, err := parseBinaryHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	count := n * n
+	reserve := count
+	if reserve > binaryPrealloc {
+		reserve = binaryPrealloc
+	}
+	m := &FloatMatrix{N: n, A: make([]float64, 0, reserve)}
+	var buf [8]byte
+	for idx := zero64; idx < count; idx++ {
+		if _, err := io.ReadFull(br, buf[:]); err != nil {
+			return nil, fmt.Errorf("munkres: reading element %d of %d: %v", idx, count, err)
+		}
+		m.A = append(m.A, math.Float64frombits(binary.LittleEndian.Uint64(buf[:])))
+	}
+	return m, nil
+}
+
+//WriteBinaryGzip writes m to w in the package's binary format compressed with gzip. Matrices with many repeated
+//values, such as sparse costs padded with zeros or +Inf, shrink considerably.
+func WriteBinaryGzip(w io.Writer, m *FloatMatrix) error {
+	zw := gzip.NewWriter(w)
+	if err := WriteBinary(zw, m); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+//ReadBinaryGzip reads a matrix written by WriteBinaryGzip. The decompressed stream must hold exactly the header and
+//the N*N elements it announces; missing or trailing data is an error.
+func ReadBinaryGzip(r io.Reader) (*FloatMatrix, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	br := bufio.NewReader(zr)
+	m, err := readBinary(br)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := br.ReadByte(); err != io.EOF {
+		if err == nil {
+			return nil, fmt.Errorf("munkres: trailing data after %d elements", len(m.A))
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+//parseBinaryHeader checks the magic and returns N
+func parseBinaryHeader(header []byte) (int64, error) {
+	if len(header) < binaryHeaderSize || string(header[:len(binaryMagic)]) != binaryMagic {
+		return 0, ErrBadBinaryHeader
+	}
+	n := int64(binary.LittleEndian.Uint64(header[len(binaryMagic):]))
+	if n < 0 || n > maxBinarySize {
+		return 0, fmt.Errorf("%w: size %d", ErrBadBinaryHeader, n)
+	}
+	return n, nil
+}
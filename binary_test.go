@@ -0,0 +1,122 @@
+package munkres
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//binaryHeader returns a header announcing a matrix of size n
+func binaryHeader(n uint64) []byte {
+	header := []byte(binaryMagic)
+	return binary.LittleEndian.AppendUint64(header, n)
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	m := randomMatrix(rand.New(rand.NewSource(4)), 5)
+	m.A[7] = math.Inf(1)
+	var buf bytes.Buffer
+	if err := WriteBinary(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != binaryHeaderSize+8*len(m.A) {
+		t.Fatalf("wrote %d bytes", buf.Len())
+	}
+	got, err := ReadBinary(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.N != m.N || !bytes.Equal(float64Bytes(got.A), float64Bytes(m.A)) {
+		t.Fatalf("read %v, wrote %v", got, m)
+	}
+}
+
+//float64Bytes returns the bit patterns of a, so that comparisons treat +Inf and NaN by bits
+func float64Bytes(a []float64) []byte {
+	var b []byte
+	for _, v := range a {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+	}
+	return b
+}
+
+func TestReadBinaryRejectsBadHeaders(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		[]byte("MUNKRES"),
+		append([]byte("NOTMUNKR"), make([]byte, 8)...),
+		binaryHeader(1 << 63),
+		binaryHeader(math.MaxInt32),
+		binaryHeader(maxBinarySize + 1),
+	} {
+		if _, err := ReadBinary(bytes.NewReader(data)); !errors.Is(err, ErrBadBinaryHeader) {
+			t.Errorf("header %q: err = %v, want ErrBadBinaryHeader", data, err)
+		}
+	}
+}
+
+func TestReadBinaryTruncatedLargeSize(t *testing.T) {
+	//a header announcing 1e5 x 1e5 elements must fail on the missing data rather than allocate 80 GB up front
+	data := append(binaryHeader(100000), make([]byte, 8*10)...)
+	if _, err := ReadBinary(bytes.NewReader(data)); err == nil {
+		t.Fatal("truncated stream was accepted")
+	}
+}
+
+func TestBinaryGzipRoundTrip(t *testing.T) {
+	m := NewMatrix(40)
+	for i := zero64; i < m.N; i++ {
+		m.SetElement(i, (i*7)%m.N, float64(i))
+	}
+	var buf bytes.Buffer
+	if err := WriteBinaryGzip(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() >= binaryHeaderSize+8*len(m.A) {
+		t.Fatalf("compressed %d elements into %d bytes", len(m.A), buf.Len())
+	}
+	got, err := ReadBinaryGzip(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.N != m.N || !bytes.Equal(float64Bytes(got.A), float64Bytes(m.A)) {
+		t.Fatal("gzip round trip changed the matrix")
+	}
+}
+
+//gzipped returns data compressed with gzip
+func gzipped(t *testing.T, data []byte) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestReadBinaryGzipValidatesLength(t *testing.T) {
+	var raw bytes.Buffer
+	if err := WriteBinary(&raw, NewMatrix(2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadBinaryGzip(gzipped(t, append(raw.Bytes(), 0))); err == nil {
+		t.Error("trailing data was accepted")
+	}
+	if _, err := ReadBinaryGzip(gzipped(t, raw.Bytes()[:raw.Len()-1])); err == nil {
+		t.Error("missing data was accepted")
+	}
+	//a payload of a few bytes announcing an enormous matrix must fail cleanly rather than crash or exhaust memory
+	for _, n := range []uint64{math.MaxInt32, 100000} {
+		if _, err := ReadBinaryGzip(gzipped(t, binaryHeader(n))); err == nil {
+			t.Errorf("header announcing size %d with no elements was accepted", n)
+		}
+	}
+}
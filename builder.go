@@ -0,0 +1,40 @@
+package munkres
+
+import "fmt"
+
+//MatrixBuilder fills a FloatMatrix cell by cell and refuses to build until every cell has been set, for callers who
+//would rather catch a forgotten cell than have it silently cost zero
+type MatrixBuilder struct {
+	m   *FloatMatrix
+	set []bool
+}
+
+//NewBuilder will return a pointer to a new MatrixBuilder for an n by n matrix
+func NewBuilder(n int64) *MatrixBuilder {
+	return &MatrixBuilder{m: NewMatrix(n), set: make([]bool, n*n)}
+}
+
+//Set sets the element at position (i,j), returning ErrOutOfRange if it lies outside the matrix.
+//Setting a cell again overwrites the earlier value.
+func (b *MatrixBuilder) Set(i, j int64, v float64) error {
+	n := b.m.N
+	if i < 0 || j < 0 || i >= n || j >= n {
+		return fmt.Errorf("munkres: element (%d,%d) of a matrix of size %d: %w", i, j, n, ErrOutOfRange)
+	}
+	b.m.SetElement(i, j, v)
+	b.set[i*n+j] = true
+	return nil
+}
+
+//Build returns a copy of the matrix built so far, or an error naming the first cell, in row order, that was never set
+func (b *MatrixBuilder) Build() (*FloatMatrix, error) {
+	n := b.m.N
+	for pos, ok := range b.set {
+		if !ok {
+			return nil, fmt.Errorf("munkres: element (%d,%d) was never set", int64(pos)/n, int64(pos)%n)
+		}
+	}
+	result := NewMatrix(n)
+	copy(result.A, b.m.A)
+	return result, nil
+}
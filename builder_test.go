@@ -0,0 +1,42 @@
+package munkres
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMatrixBuilder(t *testing.T) {
+	b := NewBuilder(2)
+	for _, c := range []struct {
+		i, j int64
+		v    float64
+	}{{0, 0, 1}, {0, 1, 2}, {1, 1, 4}} {
+		if err := b.Set(c.i, c.j, c.v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if m, err := b.Build(); m != nil || err == nil || !strings.Contains(err.Error(), "(1,0)") {
+		t.Fatalf("Build with (1,0) unset = %v, %v", m, err)
+	}
+	if err := b.Set(1, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	m, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalFloats(m.A, []float64{1, 2, 0, 4}) {
+		t.Fatalf("Build = %v, want [1 2 0 4]", m.A)
+	}
+	b.Set(0, 0, 9)
+	if m.GetElement(0, 0) != 1 {
+		t.Fatal("setting a cell after Build changed the built matrix")
+	}
+	if err := b.Set(2, 0, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("Set(2,0) err = %v, want ErrOutOfRange", err)
+	}
+	if err := b.Set(0, -1, 1); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("Set(0,-1) err = %v, want ErrOutOfRange", err)
+	}
+}
@@ -0,0 +1,105 @@
+package munkres
+
+import (
+	"container/list"
+	"math"
+	"sync"
+)
+
+//CachingSolver memoizes solves keyed by FloatMatrix.Hash in a least recently used cache, so repeated solves of an
+//identical matrix return without running the algorithm. Distinct matrices can share a hash; construct the solver
+//with verify set to keep a copy of each input and compare it on every hit. It is safe for concurrent use.
+type CachingSolver struct {
+	capacity int
+	verify   bool
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[uint64]*list.Element
+	stats   CacheStats
+}
+
+//CacheStats counts the lookups served by a CachingSolver
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+type cacheEntry struct {
+	key    uint64
+	input  *FloatMatrix
+	result []Assignment
+	total  float64
+}
+
+//NewCachingSolver returns a CachingSolver holding at most capacity solutions
+func NewCachingSolver(capacity int, verify bool) *CachingSolver {
+	return &CachingSolver{
+		capacity: capacity,
+		verify:   verify,
+		order:    list.New(),
+		entries:  make(map[uint64]*list.Element),
+	}
+}
+
+//Solve returns the cached solution for m if there is one and otherwise solves m like Solve and caches the result.
+//Errors are not cached.
+func (c *CachingSolver) Solve(m *FloatMatrix) ([]Assignment, float64, error) {
+	key := m.Hash()
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*cacheEntry)
+		if !c.verify || sameBits(entry.input, m) {
+			c.order.MoveToFront(e)
+			c.stats.Hits++
+			result := append([]Assignment(nil), entry.result...)
+			c.mu.Unlock()
+			return result, entry.total, nil
+		}
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	result, total, err := Solve(m)
+	if err != nil || c.capacity <= 0 {
+		return result, total, err
+	}
+	entry := &cacheEntry{key: key, result: append([]Assignment(nil), result...), total: total}
+	if c.verify {
+		entry.input = NewMatrix(m.N)
+		copy(entry.input.A, m.A)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return result, total, nil
+}
+
+//Stats returns the hit and miss counts so far
+func (c *CachingSolver) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+//sameBits reports whether a and b have the same size and bit-identical elements
+func sameBits(a, b *FloatMatrix) bool {
+	if a.N != b.N || len(a.A) != len(b.A) {
+		return false
+	}
+	for idx, v := range a.A {
+		if math.Float64bits(v) != math.Float64bits(b.A[idx]) {
+			return false
+		}
+	}
+	return true
+}
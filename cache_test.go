@@ -0,0 +1,64 @@
+package munkres
+
+import "testing"
+
+func TestCachingSolverHits(t *testing.T) {
+	c := NewCachingSolver(2, false)
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	first, total, err := c.Solve(m)
+	if err != nil || total != 5 {
+		t.Fatalf("first solve gave %v, %v", total, err)
+	}
+	if stats := c.Stats(); stats != (CacheStats{Misses: 1}) {
+		t.Fatalf("after the first solve: %+v", stats)
+	}
+	first[0].Cost = -1
+	again, total, err := c.Solve(&FloatMatrix{N: 3, A: append([]float64(nil), m.A...)})
+	if err != nil || total != 5 {
+		t.Fatalf("second solve gave %v, %v", total, err)
+	}
+	if stats := c.Stats(); stats != (CacheStats{Hits: 1, Misses: 1}) {
+		t.Fatalf("after solving an identical matrix: %+v", stats)
+	}
+	checkAssignment(t, m, again, total)
+}
+
+func TestCachingSolverEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewCachingSolver(2, false)
+	a := &FloatMatrix{N: 1, A: []float64{1}}
+	b := &FloatMatrix{N: 1, A: []float64{2}}
+	d := &FloatMatrix{N: 1, A: []float64{3}}
+	for _, m := range []*FloatMatrix{a, b, a, d, a, b} {
+		if _, _, err := c.Solve(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	//a hits twice; b is evicted by d after a was used more recently, so its second solve misses
+	if stats := c.Stats(); stats != (CacheStats{Hits: 2, Misses: 4}) {
+		t.Fatalf("stats %+v", stats)
+	}
+}
+
+func TestCachingSolverVerifiesHits(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 3, 4}}
+	other := &FloatMatrix{N: 2, A: []float64{4, 3, 2, 1}}
+	for _, verify := range []bool{false, true} {
+		c := NewCachingSolver(4, verify)
+		if _, _, err := c.Solve(m); err != nil {
+			t.Fatal(err)
+		}
+		//fake a hash collision by filing other's entry under m's key
+		entry := c.entries[m.Hash()].Value.(*cacheEntry)
+		if verify {
+			entry.input = other
+		}
+		entry.total = -1
+		_, total, _ := c.Solve(m)
+		if verify && total != 5 {
+			t.Fatalf("verified solve returned the colliding entry's total %v", total)
+		}
+		if !verify && total != -1 {
+			t.Fatalf("unverified solve did not use the cached entry: total %v", total)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+//go:build munkres_checks
+
+package munkres
+
+//debugChecks makes every successful solve verify its result with checkOptimality. It is enabled by the
+//munkres_checks build tag, namespaced so that applications building with tags of their own never pay for it.
+const debugChecks = true
@@ -0,0 +1,19 @@
+//go:build munkres_checks
+
+package munkres
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestChecksEnabledByTag(t *testing.T) {
+	if !debugChecks {
+		t.Fatal("munkres_checks build without debugChecks")
+	}
+	//every solve now runs checkOptimality and panics on a violation
+	r := rand.New(rand.NewSource(12))
+	for trial := 0; trial < 20; trial++ {
+		GetMunkresMinScore(randomMatrix(r, 8))
+	}
+}
@@ -0,0 +1,53 @@
+package munkres
+
+import "math/cmplx"
+
+//ComplexMatrix is a square matrix of complex128 costs, laid out like FloatMatrix
+type ComplexMatrix struct {
+	N int64
+	A []complex128
+}
+
+//NewComplexMatrix will return a pointer to a new ComplexMatrix
+func NewComplexMatrix(n int64) *ComplexMatrix {
+	return &ComplexMatrix{N: n, A: make([]complex128, n*n)}
+}
+
+//GetElement will return the element of the matrix at position (i,j)
+func (m ComplexMatrix) GetElement(i int64, j int64) complex128 {
+	return m.A[i*m.N+j]
+}
+
+//SetElement will set the element of the matrix at position (i,j)
+func (m ComplexMatrix) SetElement(i int64, j int64, v complex128) {
+	m.A[i*m.N+j] = v
+}
+
+//ComplexAssignment is a single row to column pairing chosen by SolveComplexByMagnitude along with its complex value
+type ComplexAssignment struct {
+	Row   int64
+	Col   int64
+	Value complex128
+}
+
+//SolveComplexByMagnitude returns the assignment minimizing the summed magnitude cmplx.Abs of its cells along with that
+//sum. Cells with an infinite part have infinite magnitude and are forbidden; cells with a NaN part are rejected.
+func SolveComplexByMagnitude(m *ComplexMatrix) ([]ComplexAssignment, float64, error) {
+	costs := &FloatMatrix{N: m.N, A: make([]float64, len(m.A))}
+	for idx, v := range m.A {
+		costs.A[idx] = cmplx.Abs(v)
+	}
+	if err := costs.Validate(); err != nil {
+		return nil, 0, err
+	}
+	ctx := newContext(costs)
+	if err := ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	perm := ctx.assignment()
+	result := make([]ComplexAssignment, len(perm))
+	for i, j := range perm {
+		result[i] = ComplexAssignment{Row: int64(i), Col: j, Value: m.GetElement(int64(i), j)}
+	}
+	return result, ctx.score(costs), nil
+}
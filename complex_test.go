@@ -0,0 +1,54 @@
+package munkres
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestSolveComplexByMagnitude(t *testing.T) {
+	m := NewComplexMatrix(2)
+	m.A = []complex128{3 + 4i, 1, -1i, 6 - 8i}
+	result, total, err := SolveComplexByMagnitude(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Fatalf("total = %v, want 2", total)
+	}
+	want := []ComplexAssignment{{Row: 0, Col: 1, Value: 1}, {Row: 1, Col: 0, Value: -1i}}
+	for i, a := range result {
+		if a != want[i] {
+			t.Fatalf("result = %v, want %v", result, want)
+		}
+	}
+
+	m = NewComplexMatrix(3)
+	m.A = []complex128{1 + 1i, 2, 5i, -3, 1i, 4 + 3i, 2 - 2i, 7, complex(math.Inf(1), 0)}
+	result, total, err = SolveComplexByMagnitude(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	magnitudes := NewMatrix(3)
+	for idx, v := range m.A {
+		magnitudes.A[idx] = cmplx.Abs(v)
+	}
+	if want := bruteForceMin(magnitudes); total != want {
+		t.Fatalf("total = %v, want %v", total, want)
+	}
+	var sum float64
+	for _, a := range result {
+		if a.Value != m.GetElement(a.Row, a.Col) {
+			t.Fatalf("(%d,%d) reports %v, matrix holds %v", a.Row, a.Col, a.Value, m.GetElement(a.Row, a.Col))
+		}
+		sum += cmplx.Abs(a.Value)
+	}
+	if sum != total {
+		t.Fatalf("magnitudes sum to %v, total is %v", sum, total)
+	}
+
+	m.SetElement(0, 0, complex(0, math.NaN()))
+	if _, _, err := SolveComplexByMagnitude(m); err == nil {
+		t.Fatal("NaN cell accepted")
+	}
+}
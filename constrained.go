@@ -0,0 +1,378 @@
+package munkres
+
+import (
+	"fmt"
+	"math"
+)
+
+//SolveKCardinality returns the k pairs, at most one per row and column, with the lowest total cost.
+//The matrix is padded with N-k zero cost dummy rows and columns that absorb the unmatched rows and columns.
+func SolveKCardinality(m *FloatMatrix, k int64) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	n := m.N
+	if k < 0 || k > n {
+		return nil, 0, fmt.Errorf("munkres: k = %d is outside [0, %d]", k, n)
+	}
+	size := 2*n - k
+	padded := NewMatrix(size)
+	var i, j int64
+	for i = 0; i < size; i++ {
+		for j = 0; j < size; j++ {
+			switch {
+			case i < n && j < n:
+				padded.SetElement(i, j, m.GetElement(i, j))
+			case i >= n && j >= n:
+				padded.SetElement(i, j, math.Inf(1))
+			}
+		}
+	}
+	perm, err := solvePerm(padded)
+	if err != nil {
+		return nil, 0, err
+	}
+	result, total := realAssignments(m, perm)
+	return result, total, nil
+}
+
+//SolveWithGroups returns the lowest cost assignment in which at most groupCap rows of each group are matched.
+//Since leaving rows unmatched is free, exactly min(groupCap, len(group)) rows of every group are matched, the most
+//the cap allows, and an equal number of columns is left unmatched. Rows outside every group are always matched.
+//Only the matched pairs are returned.
+func SolveWithGroups(m *FloatMatrix, groups [][]int64, groupCap int) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if groupCap < 0 {
+		return nil, 0, fmt.Errorf("munkres: negative group capacity %d", groupCap)
+	}
+	n := m.N
+	groupOf := make([]int, n)
+	for i := range groupOf {
+		groupOf[i] = -1
+	}
+	var extra []int
+	for g, rows := range groups {
+		for _, i := range rows {
+			if i < 0 || i >= n {
+				return nil, 0, fmt.Errorf("munkres: group %d names row %d outside [0, %d)", g, i, n)
+			}
+			if groupOf[i] >= 0 {
+				return nil, 0, fmt.Errorf("munkres: row %d belongs to groups %d and %d", i, groupOf[i], g)
+			}
+			groupOf[i] = g
+		}
+		for s := groupCap; s < len(rows); s++ {
+			extra = append(extra, g)
+		}
+	}
+	//each group gets dummy columns for its rows beyond the cap, and an equal number of dummy rows absorbs the
+	//real columns those rows leave behind
+	d := int64(len(extra))
+	size := n + d
+	padded := NewMatrix(size)
+	var i, j int64
+	for i = 0; i < size; i++ {
+		for j = 0; j < size; j++ {
+			switch {
+			case i < n && j < n:
+				padded.SetElement(i, j, m.GetElement(i, j))
+			case i < n && groupOf[i] != extra[j-n]:
+				padded.SetElement(i, j, math.Inf(1))
+			case i >= n && j >= n:
+				padded.SetElement(i, j, math.Inf(1))
+			}
+		}
+	}
+	perm, err := solvePerm(padded)
+	if err != nil {
+		return nil, 0, err
+	}
+	result, total := realAssignments(m, perm)
+	return result, total, nil
+}
+
+//SolveWithColumnGroupMin returns the lowest cost assignment in which at least minPerGroup columns of each group are
+//matched. A complete assignment of a square matrix matches every column, so each group receives exactly as many
+//assignments as it has columns: the constraint holds for every assignment when each group has at least minPerGroup
+//columns, which makes the plain optimum the answer, and for none otherwise, which is reported as ErrInfeasible.
+func SolveWithColumnGroupMin(m *FloatMatrix, groups [][]int64, minPerGroup int) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	n := m.N
+	groupOf := make([]int, n)
+	for j := range groupOf {
+		groupOf[j] = -1
+	}
+	for g, cols := range groups {
+		for _, j := range cols {
+			if j < 0 || j >= n {
+				return nil, 0, fmt.Errorf("munkres: group %d names column %d outside [0, %d)", g, j, n)
+			}
+			if groupOf[j] >= 0 {
+				return nil, 0, fmt.Errorf("munkres: column %d belongs to groups %d and %d", j, groupOf[j], g)
+			}
+			groupOf[j] = g
+		}
+		if len(cols) < minPerGroup {
+			return nil, 0, fmt.Errorf("munkres: group %d has %d columns, fewer than the minimum of %d: %w",
+				g, len(cols), minPerGroup, ErrInfeasible)
+		}
+	}
+	return Solve(m)
+}
+
+//solvePerm solves m and returns the column chosen for every row
+func solvePerm(m *FloatMatrix) ([]int64, error) {
+	ctx := newContext(m)
+	if err := ctx.run(); err != nil {
+		return nil, err
+	}
+	return ctx.assignment(), nil
+}
+
+//realAssignments keeps the pairs of a padded solution that lie inside m and sums their costs
+func realAssignments(m *FloatMatrix, perm []int64) ([]Assignment, float64) {
+	var total float64
+	var result []Assignment
+	for i, j := range perm[:m.N] {
+		if j < m.N {
+			a := Assignment{Row: int64(i), Col: j, Cost: m.GetElement(int64(i), j)}
+			result = append(result, a)
+			total += a.Cost
+		}
+	}
+	return result, total
+}
+
+//SolveLexicographic minimizes the total of the first objective, then among those optima the total of the second, and
+//so on. It returns the chosen assignment, with costs taken from the first objective, and the total of every objective.
+//Each level is solved over the cells whose reduced cost in the previous level is zero. Those cells may include some
+//that no optimal assignment uses, but the optimal assignments of that level are exactly the perfect matchings on them,
+//so the next level chooses among the previous optima and nothing else.
+func SolveLexicographic(objectives ...*FloatMatrix) ([]Assignment, []float64, error) {
+	if len(objectives) == 0 {
+		return nil, nil, fmt.Errorf("munkres: no objectives: %w", ErrDimensionMismatch)
+	}
+	n := objectives[0].N
+	for _, m := range objectives {
+		if err := m.Validate(); err != nil {
+			return nil, nil, err
+		}
+		if m.N != n {
+			return nil, nil, fmt.Errorf("munkres: objectives of size %d and %d: %w", n, m.N, ErrDimensionMismatch)
+		}
+	}
+	var ctx *context
+	var prevReduced []float64
+	var prevTol float64
+	for level, m := range objectives {
+		ctx = newContext(m)
+		if level > 0 {
+			for idx, v := range prevReduced {
+				if v > prevTol {
+					ctx.m.A[idx] = math.Inf(1)
+				}
+			}
+		}
+		if err := ctx.run(); err != nil {
+			return nil, nil, err
+		}
+		prevReduced, prevTol = ctx.m.A, zeroTolerance(m)
+	}
+	perm := ctx.assignment()
+	totals := make([]float64, len(objectives))
+	for level, m := range objectives {
+		totals[level], _ = m.PermutationCost(perm)
+	}
+	return assignments(objectives[0], perm), totals, nil
+}
+
+//zeroTolerance is the largest reduced cost of m that is still treated as zero when comparing optima
+func zeroTolerance(m *FloatMatrix) float64 {
+	var scale float64
+	for _, v := range m.A {
+		if !math.IsInf(v, 0) {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	return scale * 1e-12
+}
+
+//SolveDerangement returns the lowest cost assignment in which no row i is matched to column i.
+//ErrInfeasible is returned when no such assignment exists, as for a 1x1 matrix.
+func SolveDerangement(m *FloatMatrix) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	c := NewMatrix(m.N)
+	copy(c.A, m.A)
+	for i := zero64; i < m.N; i++ {
+		c.SetElement(i, i, math.Inf(1))
+	}
+	perm, err := solvePerm(c)
+	if err != nil {
+		return nil, 0, err
+	}
+	result, total := realAssignments(m, perm)
+	return result, total, nil
+}
+
+//SolveWithForbiddenAdjacency returns the lowest cost assignment avoiding every cell (i,j) for which forbidden reports
+//true, such as j == i+1 to keep a row off its neighbour's slot. The predicate is called once per cell and the cells it
+//rejects are treated as +Inf, so ErrInfeasible is returned when no assignment avoids them.
+func SolveWithForbiddenAdjacency(m *FloatMatrix, forbidden func(i, j int64) bool) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	c := NewMatrix(m.N)
+	copy(c.A, m.A)
+	for i := zero64; i < m.N; i++ {
+		for j := zero64; j < m.N; j++ {
+			if forbidden(i, j) {
+				c.SetElement(i, j, math.Inf(1))
+			}
+		}
+	}
+	perm, err := solvePerm(c)
+	if err != nil {
+		return nil, 0, err
+	}
+	result, total := realAssignments(m, perm)
+	return result, total, nil
+}
+
+//SolveMinChange re-optimizes an existing assignment, where current[i] is the column row i holds today.
+//Every cell outside current costs an extra changePenalty, so moves are only made when they save more than the penalty.
+//It returns the new assignment, with costs from m, and how many rows changed column.
+func SolveMinChange(m *FloatMatrix, current []int64, changePenalty float64) ([]Assignment, int, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if !IsValidPermutation(current, m.N) {
+		return nil, 0, ErrInvalidPermutation
+	}
+	c := NewMatrix(m.N)
+	for i := zero64; i < m.N; i++ {
+		for j := zero64; j < m.N; j++ {
+			v := m.GetElement(i, j)
+			if j != current[i] {
+				v += changePenalty
+			}
+			c.SetElement(i, j, v)
+		}
+	}
+	perm, err := solvePerm(c)
+	if err != nil {
+		return nil, 0, err
+	}
+	changed := 0
+	for i, j := range perm {
+		if j != current[i] {
+			changed++
+		}
+	}
+	return assignments(m, perm), changed, nil
+}
+
+//SolveStickyWeighted generalizes SolveMinChange with a bonus per row: row i keeping its current column current[i] is
+//credited stickiness[i], so it only moves when that saves more than its own bonus. Rows with larger bonuses are held in
+//place more firmly. It returns the new assignment, with costs from m, and how many rows changed column.
+func SolveStickyWeighted(m *FloatMatrix, current []int64, stickiness []float64) ([]Assignment, int, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if !IsValidPermutation(current, m.N) {
+		return nil, 0, ErrInvalidPermutation
+	}
+	if int64(len(stickiness)) != m.N {
+		return nil, 0, fmt.Errorf("munkres: %d stickiness weights for a matrix of size %d: %w",
+			len(stickiness), m.N, ErrDimensionMismatch)
+	}
+	c := NewMatrix(m.N)
+	copy(c.A, m.A)
+	for i, j := range current {
+		c.SetElement(int64(i), j, c.GetElement(int64(i), j)-stickiness[i])
+	}
+	if err := c.Validate(); err != nil {
+		return nil, 0, err
+	}
+	perm, err := solvePerm(c)
+	if err != nil {
+		return nil, 0, err
+	}
+	changed := 0
+	for i, j := range perm {
+		if j != current[i] {
+			changed++
+		}
+	}
+	return assignments(m, perm), changed, nil
+}
+
+//SolveWithReserve returns the lowest cost assignment in which a row may stay unmatched at a cost of reserve, so no pair
+//costing more than reserve is made unless it lowers the overall total. The matrix is padded with N dummy columns at
+//reserve cost and N dummy rows that absorb the unused columns for free. It returns the matched pairs, their total
+//excluding the reserve charges, and the unmatched rows in ascending order.
+func SolveWithReserve(m *FloatMatrix, reserve float64) ([]Assignment, float64, []int64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, nil, err
+	}
+	n := m.N
+	padded := NewMatrix(2 * n)
+	for i := zero64; i < n; i++ {
+		for j := zero64; j < n; j++ {
+			padded.SetElement(i, j, m.GetElement(i, j))
+			padded.SetElement(i, n+j, reserve)
+		}
+	}
+	perm, err := solvePerm(padded)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	result, total := realAssignments(m, perm)
+	var unassigned []int64
+	for i, j := range perm[:n] {
+		if j >= n {
+			unassigned = append(unassigned, int64(i))
+		}
+	}
+	return result, total, unassigned, nil
+}
+
+//SecondBestAssignment returns the cheapest assignment that differs from the optimum in at least one pair. Any such
+//assignment avoids at least one optimal pair, so it is found by solving once with each optimal pair forbidden in turn
+//and keeping the cheapest result, at the price of N extra solves. Its total may equal the optimum when the optimum is
+//not unique. ErrInfeasible is returned when no other assignment exists, as for a 1x1 matrix.
+func SecondBestAssignment(m *FloatMatrix) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	best, err := solvePerm(m)
+	if err != nil {
+		return nil, 0, err
+	}
+	var result []Assignment
+	total := math.Inf(1)
+	c := NewMatrix(m.N)
+	for i, j := range best {
+		copy(c.A, m.A)
+		c.SetElement(int64(i), j, math.Inf(1))
+		perm, err := solvePerm(c)
+		if err == ErrInfeasible {
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if candidate, t := realAssignments(m, perm); t < total {
+			result, total = candidate, t
+		}
+	}
+	if result == nil {
+		return nil, 0, ErrInfeasible
+	}
+	return result, total, nil
+}
@@ -0,0 +1,587 @@
+package munkres
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//checkPartial fails t unless result holds want pairs of m on distinct rows and columns whose costs sum to total
+func checkPartial(t *testing.T, m *FloatMatrix, result []Assignment, total float64, want int) {
+	t.Helper()
+	if len(result) != want {
+		t.Fatalf("%d pairs, want %d", len(result), want)
+	}
+	rows, cols := map[int64]bool{}, map[int64]bool{}
+	var sum float64
+	for _, a := range result {
+		if rows[a.Row] || cols[a.Col] {
+			t.Fatalf("pair (%d,%d) reuses a row or column", a.Row, a.Col)
+		}
+		rows[a.Row], cols[a.Col] = true, true
+		if a.Cost != m.GetElement(a.Row, a.Col) {
+			t.Fatalf("pair (%d,%d) has cost %v, matrix holds %v", a.Row, a.Col, a.Cost, m.GetElement(a.Row, a.Col))
+		}
+		sum += a.Cost
+	}
+	if sum != total {
+		t.Fatalf("pairs sum to %v, total is %v", sum, total)
+	}
+}
+
+//bruteForceK returns the lowest total of k pairs of m on distinct rows and columns
+func bruteForceK(m *FloatMatrix, k int) float64 {
+	used := make([]bool, m.N)
+	best := math.Inf(1)
+	var pick func(row int64, left int, total float64)
+	pick = func(row int64, left int, total float64) {
+		if left == 0 {
+			best = math.Min(best, total)
+			return
+		}
+		if m.N-row < int64(left) {
+			return
+		}
+		pick(row+1, left, total)
+		for j := int64(0); j < m.N; j++ {
+			if !used[j] {
+				used[j] = true
+				pick(row+1, left-1, total+m.GetElement(row, j))
+				used[j] = false
+			}
+		}
+	}
+	pick(0, k, 0)
+	return best
+}
+
+func TestSolveKCardinality(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for trial := 0; trial < 50; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(5)))
+		for k := 0; k <= int(m.N); k++ {
+			result, total, err := SolveKCardinality(m, int64(k))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkPartial(t, m, result, total, k)
+			if want := bruteForceK(m, k); total != want {
+				t.Fatalf("trial %d, k=%d: total %v, want %v", trial, k, total, want)
+			}
+		}
+	}
+}
+
+func TestSolveKCardinalityEnds(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	result, total, err := SolveKCardinality(m, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 || result[0].Row != 1 || result[0].Col != 1 {
+		t.Fatalf("k=1 chose %v totalling %v, want the zero cell (1,1)", result, total)
+	}
+	if _, total, _ = SolveKCardinality(m, m.N); total != GetMunkresMinScore(m) {
+		t.Fatalf("k=N total %v, want the full optimum %v", total, GetMunkresMinScore(m))
+	}
+	for _, k := range []int64{-1, 4} {
+		if _, _, err := SolveKCardinality(m, k); err == nil {
+			t.Fatalf("k=%d accepted", k)
+		}
+	}
+}
+
+//bruteForceGroups returns the lowest total of the partial assignments of m that match every row outside the groups
+//and exactly min(groupCap, len(group)) rows of each group
+func bruteForceGroups(m *FloatMatrix, groups [][]int64, groupCap int) float64 {
+	groupOf := make([]int, m.N)
+	for i := range groupOf {
+		groupOf[i] = -1
+	}
+	for g, rows := range groups {
+		for _, i := range rows {
+			groupOf[i] = g
+		}
+	}
+	matched := make([]int, len(groups))
+	used := make([]bool, m.N)
+	best := math.Inf(1)
+	var pick func(row int64, total float64)
+	pick = func(row int64, total float64) {
+		if row == m.N {
+			for g, rows := range groups {
+				want := groupCap
+				if len(rows) < want {
+					want = len(rows)
+				}
+				if matched[g] != want {
+					return
+				}
+			}
+			best = math.Min(best, total)
+			return
+		}
+		g := groupOf[row]
+		if g >= 0 {
+			pick(row+1, total)
+			if matched[g] == groupCap {
+				return
+			}
+			matched[g]++
+			defer func() { matched[g]-- }()
+		}
+		for j := int64(0); j < m.N; j++ {
+			if !used[j] {
+				used[j] = true
+				pick(row+1, total+m.GetElement(row, j))
+				used[j] = false
+			}
+		}
+	}
+	pick(0, 0)
+	return best
+}
+
+func TestSolveWithGroups(t *testing.T) {
+	m := &FloatMatrix{N: 4, A: []float64{
+		1, 9, 9, 9,
+		1, 9, 9, 9,
+		9, 1, 9, 9,
+		9, 9, 1, 9,
+	}}
+	result, total, err := SolveWithGroups(m, [][]int64{{0, 1}, {2, 3}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPartial(t, m, result, total, 2)
+	if total != 2 {
+		t.Fatalf("two groups of two with a cap of 1: total %v, want 2", total)
+	}
+	result, total, err = SolveWithGroups(m, [][]int64{{0, 1}}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPartial(t, m, result, total, 4)
+	if want := GetMunkresMinScore(m); total != want {
+		t.Fatalf("a cap above the group size: total %v, want the plain optimum %v", total, want)
+	}
+}
+
+func TestSolveWithGroupsMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for trial := 0; trial < 50; trial++ {
+		n := int64(2 + r.Intn(4))
+		m := randomMatrix(r, n)
+		//rows go to one of two groups or stay ungrouped
+		groups := make([][]int64, 2)
+		for i := int64(0); i < n; i++ {
+			if g := r.Intn(3); g < 2 {
+				groups[g] = append(groups[g], i)
+			}
+		}
+		groupCap := r.Intn(3)
+		_, total, err := SolveWithGroups(m, groups, groupCap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := bruteForceGroups(m, groups, groupCap); total != want {
+			t.Fatalf("trial %d: groups %v cap %d: total %v, want %v", trial, groups, groupCap, total, want)
+		}
+	}
+}
+
+func TestSolveWithGroupsRejectsBadGroups(t *testing.T) {
+	m := NewMatrix(3)
+	for _, groups := range [][][]int64{{{0, 3}}, {{0, 1}, {1, 2}}} {
+		if _, _, err := SolveWithGroups(m, groups, 1); err == nil {
+			t.Errorf("groups %v accepted", groups)
+		}
+	}
+	if _, _, err := SolveWithGroups(m, nil, -1); err == nil {
+		t.Error("negative capacity accepted")
+	}
+}
+
+//bruteForceLexicographic returns the objective totals of the permutation that is lexicographically best over every
+//permutation of the objectives' rows
+func bruteForceLexicographic(objectives []*FloatMatrix) []float64 {
+	n := objectives[0].N
+	perm := make([]int64, n)
+	for i := range perm {
+		perm[i] = int64(i)
+	}
+	var best []float64
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(perm) {
+			totals := make([]float64, len(objectives))
+			for level, m := range objectives {
+				totals[level], _ = m.PermutationCost(perm)
+			}
+			for level := range totals {
+				if best != nil && totals[level] > best[level] {
+					return
+				}
+				if best == nil || totals[level] < best[level] {
+					best = totals
+					return
+				}
+			}
+			return
+		}
+		for x := k; x < len(perm); x++ {
+			perm[k], perm[x] = perm[x], perm[k]
+			permute(k + 1)
+			perm[k], perm[x] = perm[x], perm[k]
+		}
+	}
+	permute(0)
+	return best
+}
+
+func TestSolveLexicographicMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for trial := 0; trial < 100; trial++ {
+		n := int64(1 + r.Intn(5))
+		objectives := make([]*FloatMatrix, 3)
+		for level := range objectives {
+			//few distinct costs leave many ties for the later objectives to break
+			m := NewMatrix(n)
+			for idx := range m.A {
+				m.A[idx] = float64(r.Intn(3))
+			}
+			objectives[level] = m
+		}
+		result, totals, err := SolveLexicographic(objectives...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(result)) != n {
+			t.Fatalf("trial %d: %d assignments for n=%d", trial, len(result), n)
+		}
+		want := bruteForceLexicographic(objectives)
+		for level := range want {
+			if totals[level] != want[level] {
+				t.Fatalf("trial %d: totals %v, want %v", trial, totals, want)
+			}
+		}
+	}
+}
+
+func TestSolveLexicographicRejectsMismatchedSizes(t *testing.T) {
+	if _, _, err := SolveLexicographic(NewMatrix(2), NewMatrix(3)); err == nil {
+		t.Fatal("objectives of different sizes accepted")
+	}
+	if _, _, err := SolveLexicographic(); err == nil {
+		t.Fatal("no objectives accepted")
+	}
+}
+
+func TestSolveDerangement(t *testing.T) {
+	r := rand.New(rand.NewSource(15))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(2+r.Intn(5)))
+		//cheap diagonals would win an unconstrained solve
+		for i := int64(0); i < m.N; i++ {
+			m.SetElement(i, i, 0)
+		}
+		result, total, err := SolveDerangement(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkPartial(t, m, result, total, int(m.N))
+		for _, a := range result {
+			if a.Row == a.Col {
+				t.Fatalf("trial %d: row %d kept its own column", trial, a.Row)
+			}
+		}
+		withoutDiagonal := &FloatMatrix{N: m.N, A: append([]float64(nil), m.A...)}
+		for i := int64(0); i < m.N; i++ {
+			withoutDiagonal.SetElement(i, i, math.Inf(1))
+		}
+		if want := bruteForceMin(withoutDiagonal); total != want {
+			t.Fatalf("trial %d: total %v, want %v", trial, total, want)
+		}
+	}
+	if _, _, err := SolveDerangement(NewMatrix(1)); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("1x1 matrix: err = %v, want ErrInfeasible", err)
+	}
+}
+
+func TestSolveMinChange(t *testing.T) {
+	r := rand.New(rand.NewSource(20))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(2+r.Intn(6)))
+		current := make([]int64, m.N)
+		for i, j := range r.Perm(int(m.N)) {
+			current[i] = int64(j)
+		}
+		lastChanged := int(m.N) + 1
+		for _, penalty := range []float64{0, 1, 10, 1e6} {
+			result, changed, err := SolveMinChange(m, current, penalty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var total float64
+			moved := 0
+			for _, a := range result {
+				total += a.Cost
+				if a.Col != current[a.Row] {
+					moved++
+				}
+			}
+			checkPartial(t, m, result, total, int(m.N))
+			if moved != changed {
+				t.Fatalf("trial %d: %d rows moved, %d reported", trial, moved, changed)
+			}
+			if changed > lastChanged {
+				t.Fatalf("trial %d: penalty %v changed %d rows, more than a smaller penalty", trial, penalty, changed)
+			}
+			lastChanged = changed
+			if penalty == 0 && total != GetMunkresMinScore(m) {
+				t.Fatalf("trial %d: no penalty gave %v, want the optimum %v", trial, total, GetMunkresMinScore(m))
+			}
+		}
+		if lastChanged != 0 {
+			t.Fatalf("trial %d: a prohibitive penalty still changed %d rows", trial, lastChanged)
+		}
+	}
+	if _, _, err := SolveMinChange(NewMatrix(2), []int64{0, 0}, 1); !errors.Is(err, ErrInvalidPermutation) {
+		t.Fatalf("invalid current assignment: err = %v, want ErrInvalidPermutation", err)
+	}
+}
+
+func TestSolveWithReserve(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 9, 8}}
+	result, total, unassigned, err := SolveWithReserve(m, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPartial(t, m, result, total, 1)
+	if total != 1 || result[0].Row != 0 || result[0].Col != 0 || fmt.Sprint(unassigned) != "[1]" {
+		t.Fatalf("chose %v totalling %v with %v unassigned, want row 1 left out", result, total, unassigned)
+	}
+}
+
+func TestSolveWithReserveMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(28))
+	for trial := 0; trial < 50; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(5)))
+		reserve := float64(r.Intn(50))
+		result, total, unassigned, err := SolveWithReserve(m, reserve)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkPartial(t, m, result, total, int(m.N)-len(unassigned))
+		want := math.Inf(1)
+		for k := 0; k <= int(m.N); k++ {
+			want = math.Min(want, bruteForceK(m, k)+float64(int(m.N)-k)*reserve)
+		}
+		if got := total + float64(len(unassigned))*reserve; got != want {
+			t.Fatalf("trial %d: total with reserve charges %v, want %v", trial, got, want)
+		}
+	}
+}
+
+func TestSecondBestAssignment(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	_, best, _ := Solve(m)
+	result, total, err := SecondBestAssignment(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPartial(t, m, result, total, 3)
+	if total < best {
+		t.Fatalf("second best %v beats the optimum %v", total, best)
+	}
+	_, perm := SolveScoreAndPerm(m)
+	differs := false
+	for _, a := range result {
+		differs = differs || a.Col != perm[a.Row]
+	}
+	if !differs {
+		t.Fatalf("second best %v repeats the optimum %v", result, perm)
+	}
+	if _, _, err := SecondBestAssignment(NewMatrix(1)); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("1x1 matrix: err = %v, want ErrInfeasible", err)
+	}
+}
+
+func TestSecondBestAssignmentMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(38))
+	for trial := 0; trial < 50; trial++ {
+		m := randomMatrix(r, int64(2+r.Intn(4)))
+		_, optimum := SolveScoreAndPerm(m)
+		//the cheapest permutation other than the optimum
+		want := math.Inf(1)
+		perm := make([]int64, m.N)
+		for i := range perm {
+			perm[i] = int64(i)
+		}
+		var permute func(k int)
+		permute = func(k int) {
+			if k == len(perm) {
+				for i := range perm {
+					if perm[i] != optimum[i] {
+						total, _ := m.PermutationCost(perm)
+						want = math.Min(want, total)
+						return
+					}
+				}
+				return
+			}
+			for x := k; x < len(perm); x++ {
+				perm[k], perm[x] = perm[x], perm[k]
+				permute(k + 1)
+				perm[k], perm[x] = perm[x], perm[k]
+			}
+		}
+		permute(0)
+		if _, total, err := SecondBestAssignment(m); err != nil || total != want {
+			t.Fatalf("trial %d: second best %v (%v), want %v", trial, total, err, want)
+		}
+	}
+}
+
+func TestSolveWithForbiddenAdjacency(t *testing.T) {
+	adjacent := func(i, j int64) bool { return j == i+1 }
+	r := rand.New(rand.NewSource(183))
+	for trial := 0; trial < 40; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(6)))
+		result, total, err := SolveWithForbiddenAdjacency(m, adjacent)
+		if err != nil {
+			t.Fatalf("trial %d: %v", trial, err)
+		}
+		checkAssignment(t, m, result, total)
+		for _, a := range result {
+			if adjacent(a.Row, a.Col) {
+				t.Fatalf("trial %d: assigned forbidden cell (%d,%d)", trial, a.Row, a.Col)
+			}
+		}
+		masked := NewMatrix(m.N)
+		copy(masked.A, m.A)
+		for i := int64(0); i+1 < m.N; i++ {
+			masked.SetElement(i, i+1, math.Inf(1))
+		}
+		if want := bruteForceMin(masked); total != want {
+			t.Fatalf("trial %d: total %v, want %v", trial, total, want)
+		}
+	}
+	m := randomMatrix(r, 3)
+	firstColumn := func(i, j int64) bool { return j == 0 }
+	if _, _, err := SolveWithForbiddenAdjacency(m, firstColumn); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("forbidden column: err = %v, want ErrInfeasible", err)
+	}
+}
+
+func TestSolveStickyWeighted(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{
+		0, 1, 1,
+		1, 0, 1,
+		1, 1, 0,
+	}}
+	current := []int64{1, 0, 2}
+	for _, c := range []struct {
+		stickiness []float64
+		cols       []int64
+		changed    int
+	}{
+		{[]float64{0, 0, 0}, []int64{0, 1, 2}, 2},
+		{[]float64{0.5, 0, 0}, []int64{0, 1, 2}, 2},
+		{[]float64{3, 0, 0}, []int64{1, 0, 2}, 0},
+	} {
+		result, changed, err := SolveStickyWeighted(m, current, c.stickiness)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed != c.changed {
+			t.Fatalf("stickiness %v: %d rows changed, want %d", c.stickiness, changed, c.changed)
+		}
+		for i, a := range result {
+			if a.Col != c.cols[i] || a.Cost != m.GetElement(a.Row, a.Col) {
+				t.Fatalf("stickiness %v: assignment %v, want columns %v", c.stickiness, result, c.cols)
+			}
+		}
+	}
+
+	r := rand.New(rand.NewSource(191))
+	for trial := 0; trial < 20; trial++ {
+		m := randomMatrix(r, int64(2+r.Intn(5)))
+		current := make([]int64, m.N)
+		stickiness := make([]float64, m.N)
+		for i, j := range r.Perm(int(m.N)) {
+			current[i] = int64(j)
+			stickiness[i] = 7
+		}
+		sticky, _, err := SolveStickyWeighted(m, current, stickiness)
+		if err != nil {
+			t.Fatal(err)
+		}
+		minChange, _, err := SolveMinChange(m, current, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stickyTotal, minChangeTotal float64
+		for i := range sticky {
+			stickyTotal += sticky[i].Cost
+			if sticky[i].Col == current[i] {
+				stickyTotal -= 7
+			}
+			minChangeTotal += minChange[i].Cost
+			if minChange[i].Col == current[i] {
+				minChangeTotal -= 7
+			}
+		}
+		if stickyTotal != minChangeTotal {
+			t.Fatalf("trial %d: uniform stickiness scores %v, SolveMinChange %v", trial, stickyTotal, minChangeTotal)
+		}
+	}
+
+	if _, _, err := SolveStickyWeighted(m, current, []float64{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("short stickiness: err = %v, want ErrDimensionMismatch", err)
+	}
+	if _, _, err := SolveStickyWeighted(m, []int64{0, 0, 1}, make([]float64, 3)); !errors.Is(err, ErrInvalidPermutation) {
+		t.Fatalf("invalid current: err = %v, want ErrInvalidPermutation", err)
+	}
+}
+
+func TestSolveWithColumnGroupMin(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{
+		1, 2, 9,
+		2, 1, 9,
+		1, 1, 8,
+	}}
+	groups := [][]int64{{0, 1}, {2}}
+	result, total, err := SolveWithColumnGroupMin(m, groups, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if want := GetMunkresMinScore(m); total != want {
+		t.Fatalf("total = %v, want %v", total, want)
+	}
+	counts := make([]int, len(groups))
+	for _, a := range result {
+		for g, cols := range groups {
+			for _, j := range cols {
+				if a.Col == j {
+					counts[g]++
+				}
+			}
+		}
+	}
+	if counts[0] < 1 || counts[1] < 1 {
+		t.Fatalf("assignment %v puts %v pairs in the groups, want at least 1 each", result, counts)
+	}
+	if _, _, err := SolveWithColumnGroupMin(m, groups, 2); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("minimum above a group's size: err = %v, want ErrInfeasible", err)
+	}
+	if _, _, err := SolveWithColumnGroupMin(m, [][]int64{{0, 1}, {1, 2}}, 1); err == nil {
+		t.Fatal("overlapping groups accepted")
+	}
+	if _, _, err := SolveWithColumnGroupMin(m, [][]int64{{0, 3}}, 1); err == nil {
+		t.Fatal("group naming a column outside the matrix accepted")
+	}
+}
@@ -0,0 +1,86 @@
+package munkres
+
+import (
+	"math"
+	"sync"
+)
+
+//SolveDecomposed splits m into the independent blocks found by FindIndependentBlocks, solves the blocks on up to
+//workers goroutines and stitches their assignments back together. Cells at or above forbiddenSentinel, like +Inf
+//cells, are forbidden. No assignment can cross between blocks, so the result is as optimal as a single solve, while each
+//block costs only the cube of its own size. The assignment is listed in row order and the total is summed in that
+//order, so the result does not depend on workers. ErrInfeasible is returned if some block holds more rows than columns
+//or the reverse, or cannot otherwise be matched.
+func SolveDecomposed(m *FloatMatrix, forbiddenSentinel float64, workers int) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	n := m.N
+	var rowsOf, colsOf [][]int64
+	for _, block := range FindIndependentBlocks(m, forbiddenSentinel) {
+		var rows, cols []int64
+		for _, v := range block {
+			if v < n {
+				rows = append(rows, v)
+			} else {
+				cols = append(cols, v-n)
+			}
+		}
+		if len(rows) != len(cols) {
+			return nil, 0, ErrInfeasible
+		}
+		rowsOf = append(rowsOf, rows)
+		colsOf = append(colsOf, cols)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	perms := make([][]int64, len(rowsOf))
+	errs := make([]error, len(rowsOf))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range next {
+				perms[b], errs[b] = solvePerm(subMatrix(m, rowsOf[b], colsOf[b], forbiddenSentinel))
+			}
+		}()
+	}
+	for b := range rowsOf {
+		next <- b
+	}
+	close(next)
+	wg.Wait()
+	result := make([]Assignment, n)
+	for b, rows := range rowsOf {
+		if errs[b] != nil {
+			return nil, 0, errs[b]
+		}
+		for k, i := range rows {
+			j := colsOf[b][perms[b][k]]
+			result[i] = Assignment{Row: i, Col: j, Cost: m.GetElement(i, j)}
+		}
+	}
+	var total float64
+	for _, a := range result {
+		total += a.Cost
+	}
+	return result, total, nil
+}
+
+//subMatrix returns the square matrix of m's cells at the given rows and columns, forbidding those at or above sentinel
+func subMatrix(m *FloatMatrix, rows, cols []int64, sentinel float64) *FloatMatrix {
+	sub := NewMatrix(int64(len(rows)))
+	for a, i := range rows {
+		for b, j := range cols {
+			v := m.GetElement(i, j)
+			if v >= sentinel {
+				v = math.Inf(1)
+			}
+			sub.SetElement(int64(a), int64(b), v)
+		}
+	}
+	return sub
+}
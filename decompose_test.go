@@ -0,0 +1,73 @@
+package munkres
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSolveDecomposedMatchesMonolithic(t *testing.T) {
+	r := rand.New(rand.NewSource(189))
+	for trial := 0; trial < 30; trial++ {
+		//a block-diagonal matrix with blocks of random sizes, its rows and columns shuffled
+		var sizes []int64
+		var n int64
+		for n < 12 {
+			size := int64(1 + r.Intn(4))
+			sizes = append(sizes, size)
+			n += size
+		}
+		rowPerm, colPerm := r.Perm(int(n)), r.Perm(int(n))
+		m := NewMatrix(n)
+		for idx := range m.A {
+			m.A[idx] = math.Inf(1)
+		}
+		var start int64
+		for _, size := range sizes {
+			for i := start; i < start+size; i++ {
+				for j := start; j < start+size; j++ {
+					m.SetElement(int64(rowPerm[i]), int64(colPerm[j]), float64(r.Intn(50)))
+				}
+			}
+			start += size
+		}
+		_, want, err := NewSolver().Solve(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, workers := range []int{0, 1, 4} {
+			result, total, err := SolveDecomposed(m, math.Inf(1), workers)
+			if err != nil {
+				t.Fatalf("trial %d, %d workers: %v", trial, workers, err)
+			}
+			checkAssignment(t, m, result, total)
+			if total != want {
+				t.Fatalf("trial %d, %d workers: total %v, monolithic solve %v", trial, workers, total, want)
+			}
+		}
+	}
+}
+
+func TestSolveDecomposedSentinel(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{
+		1, 99, 99,
+		99, 2, 5,
+		99, 4, 3,
+	}}
+	result, total, err := SolveDecomposed(m, 99, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if total != 6 {
+		t.Fatalf("total = %v, want 6", total)
+	}
+}
+
+func TestSolveDecomposedInfeasible(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, math.Inf(1), 2, math.Inf(1)}}
+	if _, _, err := SolveDecomposed(m, math.Inf(1), 2); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("err = %v, want ErrInfeasible", err)
+	}
+}
@@ -0,0 +1,45 @@
+package munkres
+
+import "time"
+
+//DurationMatrix is a square matrix of time.Duration costs, laid out like FloatMatrix
+type DurationMatrix struct {
+	N int64
+	A []time.Duration
+}
+
+//NewDurationMatrix will return a pointer to a new DurationMatrix
+func NewDurationMatrix(n int64) *DurationMatrix {
+	return &DurationMatrix{N: n, A: make([]time.Duration, n*n)}
+}
+
+//GetElement will return the element of the matrix at position (i,j)
+func (m DurationMatrix) GetElement(i int64, j int64) time.Duration {
+	return m.A[i*m.N+j]
+}
+
+//SetElement will set the element of the matrix at position (i,j)
+func (m DurationMatrix) SetElement(i int64, j int64, v time.Duration) {
+	m.A[i*m.N+j] = v
+}
+
+//SolveDuration returns the lowest total duration and the assignment achieving it, with each cost in nanoseconds.
+//Costs are solved as float64 nanoseconds, which are exact up to about 104 days, but the total is summed from the
+//original durations. It panics with ErrNotSquare if A does not hold N*N elements, and with the solver's error should
+//the solve fail, which finite durations never cause.
+func SolveDuration(m *DurationMatrix) (time.Duration, []Assignment) {
+	costs := &FloatMatrix{N: m.N, A: make([]float64, len(m.A))}
+	for idx, d := range m.A {
+		costs.A[idx] = float64(d)
+	}
+	ctx := newContext(costs)
+	if err := ctx.run(); err != nil {
+		panic(err)
+	}
+	perm := ctx.assignment()
+	var total time.Duration
+	for i, j := range perm {
+		total += m.GetElement(int64(i), j)
+	}
+	return total, assignments(costs, perm)
+}
@@ -0,0 +1,43 @@
+package munkres
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSolveDuration(t *testing.T) {
+	m := NewDurationMatrix(3)
+	copy(m.A, []time.Duration{
+		4 * time.Hour, time.Second, 3 * time.Minute,
+		2 * time.Second, 0, 5 * time.Second,
+		3 * time.Second, 2 * time.Second, 2 * time.Second,
+	})
+	total, result := SolveDuration(m)
+	if want := 5 * time.Second; total != want {
+		t.Fatalf("total = %v, want %v", total, want)
+	}
+	if len(result) != 3 {
+		t.Fatalf("%d assignments, want 3", len(result))
+	}
+	var sum time.Duration
+	for _, a := range result {
+		if time.Duration(a.Cost) != m.GetElement(a.Row, a.Col) {
+			t.Fatalf("assignment %+v costs %v in the matrix", a, m.GetElement(a.Row, a.Col))
+		}
+		sum += time.Duration(a.Cost)
+	}
+	if sum != total {
+		t.Fatalf("assignments sum to %v, total is %v", sum, total)
+	}
+}
+
+func TestSolveDurationPanicsOnBadShape(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrNotSquare) {
+			t.Fatalf("recovered %v, want ErrNotSquare", err)
+		}
+	}()
+	SolveDuration(&DurationMatrix{N: 2, A: make([]time.Duration, 3)})
+}
@@ -0,0 +1,152 @@
+package munkres
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//maxTextLine is the longest line ReadMatrixText accepts; a line holds a whole row, so it grows with N
+const maxTextLine = 1 << 30
+
+//ReadMatrixText reads a square matrix written as one row per line with elements separated by any whitespace.
+//Blank lines are ignored.
+func ReadMatrixText(r io.Reader) (*FloatMatrix, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxTextLine)
+	var a []float64
+	var n, rows int64
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if rows == 0 {
+			n = int64(len(fields))
+			a = make([]float64, 0, n*n)
+		} else if int64(len(fields)) != n {
+			return nil, fmt.Errorf("munkres: line %d has %d elements, expected %d", line, len(fields), n)
+		}
+		for _, f := range fields {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return nil, fmt.Errorf("munkres: line %d: %v", line, err)
+			}
+			a = append(a, v)
+		}
+		rows++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if rows != n {
+		return nil, fmt.Errorf("munkres: read %d rows of %d elements: %w", rows, n, ErrNotSquare)
+	}
+	return &FloatMatrix{N: n, A: a}, nil
+}
+
+//DecodeMatrixJSON reads a square matrix encoded as a JSON array of row arrays, decoding one row at a time
+//so the whole document is never buffered. Rows are checked against the first row's length as they arrive, and
+//anything but whitespace after the closing bracket is an error.
+func DecodeMatrixJSON(r io.Reader) (*FloatMatrix, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+	var a []float64
+	var n, rows int64
+	for dec.More() {
+		var row []float64
+		if err := dec.Decode(&row); err != nil {
+			return nil, fmt.Errorf("munkres: row %d: %v", rows, err)
+		}
+		if rows == 0 {
+			n = int64(len(row))
+			a = make([]float64, 0, n*n)
+		}
+		if int64(len(row)) != n {
+			return nil, fmt.Errorf("munkres: row %d has %d elements, expected %d: %w", rows, len(row), n, ErrNotSquare)
+		}
+		if rows == n {
+			return nil, fmt.Errorf("munkres: more than %d rows of %d elements: %w", n, n, ErrNotSquare)
+		}
+		a = append(a, row...)
+		rows++
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return nil, err
+	}
+	if rows != n {
+		return nil, fmt.Errorf("munkres: read %d rows of %d elements: %w", rows, n, ErrNotSquare)
+	}
+	if tok, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("munkres: unexpected %v after matrix JSON", tok)
+	}
+	return &FloatMatrix{N: n, A: a}, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("munkres: expected %v in matrix JSON, found %v", want, tok)
+	}
+	return nil
+}
+
+//solveReport is the document written by SolveReportJSON
+type solveReport struct {
+	N           int64              `json:"n"`
+	Score       float64            `json:"score"`
+	Assignments []reportAssignment `json:"assignments"`
+	Stats       reportStats        `json:"stats"`
+}
+
+type reportAssignment struct {
+	Row  int64   `json:"row"`
+	Col  int64   `json:"col"`
+	Cost float64 `json:"cost"`
+}
+
+type reportStats struct {
+	StepCounts          [6]int `json:"stepCounts"`
+	InitialStarComplete bool   `json:"initialStarComplete"`
+	Step6Zeros          []int  `json:"step6Zeros"`
+}
+
+//SolveReportJSON solves m and returns a JSON document for audit logs. It records the matrix size as "n", the lowest
+//total as "score", every chosen pair in row order with its cost under "assignments" and the SolveStats of the run
+//under "stats", with field names in lower camel case.
+func SolveReportJSON(m *FloatMatrix) ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	ctx := newContext(m)
+	if err := ctx.run(); err != nil {
+		return nil, err
+	}
+	report := solveReport{
+		N:     m.N,
+		Score: ctx.score(m),
+		Stats: reportStats{
+			StepCounts:          ctx.stats.StepCounts,
+			InitialStarComplete: ctx.stats.InitialStarComplete,
+			Step6Zeros:          append([]int{}, ctx.stats.Step6Zeros...),
+		},
+	}
+	report.Assignments = make([]reportAssignment, 0, m.N)
+	for _, a := range assignments(m, ctx.assignment()) {
+		report.Assignments = append(report.Assignments, reportAssignment(a))
+	}
+	return json.Marshal(report)
+}
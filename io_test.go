@@ -0,0 +1,110 @@
+package munkres
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixText(t *testing.T) {
+	for _, text := range []string{"1\t2\n3\t4", "1   2\n\n  3 4\n\n", "1 2\n3 4\n"} {
+		m, err := ReadMatrixText(strings.NewReader(text))
+		if err != nil {
+			t.Fatalf("%q: %v", text, err)
+		}
+		if m.N != 2 || m.GetElement(1, 0) != 3 || m.GetElement(1, 1) != 4 {
+			t.Fatalf("%q: read %v", text, m.A)
+		}
+	}
+	m, err := ReadMatrixText(strings.NewReader(""))
+	if err != nil || m.N != 0 {
+		t.Fatalf("empty input: %v, %v", m, err)
+	}
+	for _, text := range []string{"1 2\n3 4\n5 6", "1 2\n3\n", "1 x\n3 4"} {
+		if _, err := ReadMatrixText(strings.NewReader(text)); err == nil {
+			t.Errorf("%q accepted", text)
+		}
+	}
+}
+
+func TestReadMatrixTextLongLines(t *testing.T) {
+	//a row of a 5000 column matrix runs past the 64 KB line limit of a default bufio.Scanner; padding a small row
+	//with whitespace makes a line just as long
+	pad := strings.Repeat(" ", 100000)
+	text := "1 2 3\n4" + pad + "5 6\n7 8 9\n"
+	m, err := ReadMatrixText(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.N != 3 || m.GetElement(1, 1) != 5 {
+		t.Fatalf("read %v", m.A)
+	}
+}
+
+func TestDecodeMatrixJSON(t *testing.T) {
+	m, err := DecodeMatrixJSON(strings.NewReader("[[1,2],[3,4]]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.N != 2 || m.GetElement(1, 0) != 3 {
+		t.Fatalf("decoded %v", m.A)
+	}
+	if _, err := DecodeMatrixJSON(strings.NewReader("[[1,2],[3]]")); !errors.Is(err, ErrNotSquare) {
+		t.Fatalf("ragged rows: err = %v, want ErrNotSquare", err)
+	}
+	for _, doc := range []string{"[[1,2],[3,4]] garbage", "[[1,2],[3,4]][[5]]", "[[1]] 7"} {
+		if _, err := DecodeMatrixJSON(strings.NewReader(doc)); err == nil {
+			t.Errorf("%q: trailing data accepted", doc)
+		}
+	}
+}
+
+func TestSolveReportJSON(t *testing.T) {
+	r := rand.New(rand.NewSource(192))
+	for trial := 0; trial < 20; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(6)))
+		data, err := SolveReportJSON(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []string{"n", "score", "assignments", "stats"} {
+			if _, ok := fields[key]; !ok {
+				t.Fatalf("report %s has no %q", data, key)
+			}
+		}
+		var report solveReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			t.Fatal(err)
+		}
+		s := NewSolver()
+		result, total, err := s.Solve(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.N != m.N || report.Score != total || len(report.Assignments) != len(result) {
+			t.Fatalf("trial %d: report %s, solver total %v", trial, data, total)
+		}
+		for i, a := range report.Assignments {
+			if Assignment(a) != result[i] {
+				t.Fatalf("trial %d: reported pair %+v, solver chose %+v", trial, a, result[i])
+			}
+		}
+		stats := s.Stats()
+		if report.Stats.StepCounts != stats.StepCounts ||
+			report.Stats.InitialStarComplete != stats.InitialStarComplete ||
+			!equalInts(report.Stats.Step6Zeros, stats.Step6Zeros) {
+			t.Fatalf("trial %d: reported stats %+v, solver stats %+v", trial, report.Stats, stats)
+		}
+	}
+	m := &FloatMatrix{N: 2, A: []float64{math.Inf(1), 1, math.Inf(1), 2}}
+	if _, err := SolveReportJSON(m); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("infeasible matrix: err = %v, want ErrInfeasible", err)
+	}
+}
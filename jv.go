@@ -0,0 +1,78 @@
+package munkres
+
+import "math"
+
+//GetMinScoreJV returns the same lowest total as GetMunkresMinScore, computed by shortest augmenting paths instead of
+//the covering steps. Rows are added one at a time, each along the cheapest path in reduced costs to a free column
+//found by a Dijkstra-like scan that keeps row and column potentials, so the work per row is O(N^2) with none of the
+//repeated full-matrix searches of steps 4 and 6. This is the augmentation phase shared by the Jonker-Volgenant
+//algorithm, without its column reduction and augmenting row reduction initialization. It is much faster on large
+//dense matrices. The total is summed over the chosen cells in row order. It returns +Inf if no assignment avoids the
+//forbidden cells and panics with ErrNotSquare if A does not hold N*N elements.
+func GetMinScoreJV(m *FloatMatrix) float64 {
+	if !m.isSquare() {
+		panic(ErrNotSquare)
+	}
+	n := m.N
+	//Index 0 of every slice below is a virtual column holding the row being added, so real rows and columns are 1..N
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	rowOf := make([]int64, n+1)
+	way := make([]int64, n+1)
+	minv := make([]float64, n+1)
+	used := make([]bool, n+1)
+	for i := int64(1); i <= n; i++ {
+		rowOf[0] = i
+		j0 := zero64
+		for j := range minv {
+			minv[j] = math.Inf(1)
+			used[j] = false
+		}
+		for rowOf[j0] != 0 {
+			used[j0] = true
+			i0 := rowOf[j0]
+			row := m.A[(i0-1)*n : i0*n]
+			delta := math.Inf(1)
+			j1 := zero64
+			for j := int64(1); j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := row[j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			if math.IsInf(delta, 1) {
+				return math.Inf(1)
+			}
+			for j := zero64; j <= n; j++ {
+				if used[j] {
+					u[rowOf[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			rowOf[j0] = rowOf[j1]
+			j0 = j1
+		}
+	}
+	colOf := make([]int64, n)
+	for j := int64(1); j <= n; j++ {
+		colOf[rowOf[j]-1] = j - 1
+	}
+	var total float64
+	for i, j := range colOf {
+		total += m.GetElement(int64(i), j)
+	}
+	return total
+}
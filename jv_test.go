@@ -0,0 +1,46 @@
+package munkres
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGetMinScoreJVMatchesMunkres(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for trial := 0; trial < 300; trial++ {
+		n := int64(r.Intn(12))
+		m := randomMatrix(r, n)
+		if trial%3 == 0 {
+			for k := zero64; k < n; k++ {
+				m.A[r.Intn(len(m.A))] = math.Inf(1)
+			}
+		}
+		if trial%5 == 0 {
+			for idx := range m.A {
+				m.A[idx] -= 25
+			}
+		}
+		if got, want := GetMinScoreJV(m), GetMunkresMinScore(m); got != want {
+			t.Fatalf("trial %d: GetMinScoreJV = %v, GetMunkresMinScore = %v", trial, got, want)
+		}
+	}
+}
+
+func BenchmarkMinScore500(b *testing.B) {
+	r := rand.New(rand.NewSource(8))
+	m := NewMatrix(500)
+	for idx := range m.A {
+		m.A[idx] = r.Float64()
+	}
+	b.Run("JV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			GetMinScoreJV(m)
+		}
+	})
+	b.Run("Munkres", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			GetMunkresMinScore(m)
+		}
+	})
+}
@@ -0,0 +1,433 @@
+package munkres
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//MulScalar returns a new matrix holding every element of m multiplied by f, leaving m untouched
+func (m *FloatMatrix) MulScalar(f float64) *FloatMatrix {
+	result := NewMatrix(m.N)
+	for idx, v := range m.A {
+		result.A[idx] = v * f
+	}
+	return result
+}
+
+//Round returns a new matrix holding every element of m rounded half away from zero to the given number of decimal
+//places, leaving m untouched; a negative count rounds to tens, hundreds and so on. It is meant for display and for
+//post-processing results. Rounding before solving can merge nearly equal costs and so change the optimal assignment.
+//Elements whose scaled value would overflow, and every element when 10^decimals itself overflows or underflows
+//float64, are copied unchanged rather than turned into +Inf, which would forbid the cell, or NaN.
+func (m *FloatMatrix) Round(decimals int) *FloatMatrix {
+	scale := math.Pow10(decimals)
+	result := NewMatrix(m.N)
+	for idx, v := range m.A {
+		if scaled := v * scale; scale != 0 && !math.IsInf(scale, 0) && !math.IsInf(scaled, 0) {
+			v = math.Round(scaled) / scale
+		}
+		result.A[idx] = v
+	}
+	return result
+}
+
+//NormalizeRows scales each row in place so that its elements sum to 1.
+//Rows summing to zero are left unchanged, as are forbidden (+Inf) cells, which are excluded from the sum.
+func (m *FloatMatrix) NormalizeRows() {
+	n := m.N
+	for i := zero64; i < n; i++ {
+		row := m.A[i*n : (i+1)*n]
+		var sum float64
+		for _, v := range row {
+			if !math.IsInf(v, 1) {
+				sum += v
+			}
+		}
+		if sum == 0 {
+			continue
+		}
+		for idx, v := range row {
+			if !math.IsInf(v, 1) {
+				row[idx] = v / sum
+			}
+		}
+	}
+}
+
+//NormalizeMinMax linearly rescales the matrix in place so its smallest element becomes 0 and its largest 1.
+//Forbidden (+Inf) cells are ignored and left unchanged; if every other element is equal they all become 0.
+func (m *FloatMatrix) NormalizeMinMax() {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range m.A {
+		if math.IsInf(v, 1) {
+			continue
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	spread := hi - lo
+	for idx, v := range m.A {
+		switch {
+		case math.IsInf(v, 1):
+		case spread == 0:
+			m.A[idx] = 0
+		default:
+			m.A[idx] = (v - lo) / spread
+		}
+	}
+}
+
+//DynamicRangeWarning is the DynamicRange above which a matrix deserves scrutiny. float64 carries about 16 significant
+//digits, so beyond a range of 1e9 the smallest costs keep fewer than 7 of them once the steps have added and subtracted
+//costs near the largest magnitude, and reduced costs that should be exactly zero may miss it.
+const DynamicRangeWarning = 1e9
+
+//DynamicRange returns the ratio of the largest to the smallest nonzero magnitude among the finite elements, a cheap
+//indicator of inputs prone to rounding trouble; compare it against DynamicRangeWarning. Zeros, infinities and NaNs are
+//skipped, and a matrix with no nonzero finite element reports 1.
+func (m *FloatMatrix) DynamicRange() float64 {
+	lo, hi := math.Inf(1), 0.0
+	for _, v := range m.A {
+		a := math.Abs(v)
+		if a == 0 || math.IsInf(a, 1) || math.IsNaN(a) {
+			continue
+		}
+		lo = math.Min(lo, a)
+		hi = math.Max(hi, a)
+	}
+	if hi == 0 {
+		return 1
+	}
+	return hi / lo
+}
+
+//GetDiagonal returns a copy of the elements at positions (i,i)
+func (m *FloatMatrix) GetDiagonal() []float64 {
+	diag := make([]float64, m.N)
+	for i := zero64; i < m.N; i++ {
+		diag[i] = m.GetElement(i, i)
+	}
+	return diag
+}
+
+//SetDiagonal sets the elements at positions (i,i) to vals, which must hold exactly N values
+func (m *FloatMatrix) SetDiagonal(vals []float64) error {
+	if int64(len(vals)) != m.N {
+		return fmt.Errorf("munkres: %d diagonal values for a matrix of size %d: %w", len(vals), m.N, ErrDimensionMismatch)
+	}
+	for i, v := range vals {
+		m.SetElement(int64(i), int64(i), v)
+	}
+	return nil
+}
+
+//PermutationCost returns the total cost of assigning every row i to column perm[i]
+func (m *FloatMatrix) PermutationCost(perm []int64) (float64, error) {
+	if !IsValidPermutation(perm, m.N) {
+		return 0, ErrInvalidPermutation
+	}
+	var total float64
+	for i, j := range perm {
+		total += m.GetElement(int64(i), j)
+	}
+	return total, nil
+}
+
+//NewMatrixFromRows returns a new FloatMatrix holding a copy of rows, which must form a square
+func NewMatrixFromRows(rows [][]float64) (*FloatMatrix, error) {
+	n := int64(len(rows))
+	m := NewMatrix(n)
+	for i, row := range rows {
+		if int64(len(row)) != n {
+			return nil, fmt.Errorf("munkres: row %d has %d elements, expected %d: %w", i, len(row), n, ErrNotSquare)
+		}
+		copy(m.A[int64(i)*n:], row)
+	}
+	return m, nil
+}
+
+//NewSymmetricMatrixFromUpper returns the symmetric n by n matrix whose upper triangle, diagonal included, is upper
+//read row by row: (0,0), (0,1), ..., (0,n-1), (1,1), ..., (n-1,n-1). Each value is mirrored below the diagonal.
+//upper must hold exactly n*(n+1)/2 values.
+func NewSymmetricMatrixFromUpper(n int64, upper []float64) (*FloatMatrix, error) {
+	if n < 0 || int64(len(upper)) != n*(n+1)/2 {
+		return nil, fmt.Errorf("munkres: %d upper triangle values for a matrix of size %d: %w", len(upper), n, ErrDimensionMismatch)
+	}
+	m := NewMatrix(n)
+	k := 0
+	for i := zero64; i < n; i++ {
+		for j := i; j < n; j++ {
+			m.SetElement(i, j, upper[k])
+			m.SetElement(j, i, upper[k])
+			k++
+		}
+	}
+	return m, nil
+}
+
+//NewMatrixFromNestedMap builds a matrix from costs[rowKey][colKey], ordering rows as rowKeys and columns as colKeys and
+//filling cells absent from costs with missing, such as +Inf to forbid them. A nil rowKeys uses the sorted keys of costs
+//and a nil colKeys the sorted union of the inner keys; entries under other keys are ignored. The key orders used are
+//returned so that assignments can be decoded back to keys. Both must have the same length and no duplicates.
+func NewMatrixFromNestedMap(rowKeys, colKeys []string, costs map[string]map[string]float64,
+	missing float64) (*FloatMatrix, []string, []string, error) {
+	if rowKeys == nil {
+		for k := range costs {
+			rowKeys = append(rowKeys, k)
+		}
+		sort.Strings(rowKeys)
+	} else {
+		rowKeys = append([]string(nil), rowKeys...)
+	}
+	if colKeys == nil {
+		seen := make(map[string]bool)
+		for _, row := range costs {
+			for k := range row {
+				if !seen[k] {
+					seen[k] = true
+					colKeys = append(colKeys, k)
+				}
+			}
+		}
+		sort.Strings(colKeys)
+	} else {
+		colKeys = append([]string(nil), colKeys...)
+	}
+	if len(rowKeys) != len(colKeys) {
+		return nil, nil, nil, fmt.Errorf("munkres: %d row keys and %d column keys: %w", len(rowKeys), len(colKeys), ErrNotSquare)
+	}
+	if err := checkUniqueKeys(rowKeys); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkUniqueKeys(colKeys); err != nil {
+		return nil, nil, nil, err
+	}
+	m := NewMatrix(int64(len(rowKeys)))
+	for i, rk := range rowKeys {
+		row := costs[rk]
+		for j, ck := range colKeys {
+			v, ok := row[ck]
+			if !ok {
+				v = missing
+			}
+			m.SetElement(int64(i), int64(j), v)
+		}
+	}
+	return m, rowKeys, colKeys, nil
+}
+
+//checkUniqueKeys returns an error naming the first key that appears more than once
+func checkUniqueKeys(keys []string) error {
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if seen[k] {
+			return fmt.Errorf("munkres: duplicate key %q", k)
+		}
+		seen[k] = true
+	}
+	return nil
+}
+
+//ToRows returns a copy of the matrix as a slice of rows
+func (m *FloatMatrix) ToRows() [][]float64 {
+	rows := make([][]float64, m.N)
+	for i := range rows {
+		rows[i] = make([]float64, m.N)
+		copy(rows[i], m.A[int64(i)*m.N:])
+	}
+	return rows
+}
+
+//Data returns a copy of the elements in row-major order, element (i,j) at index i*N+j.
+//Changing the copy does not affect the matrix.
+func (m *FloatMatrix) Data() []float64 {
+	return append([]float64(nil), m.A...)
+}
+
+//NewDistanceMatrix returns a matrix whose element (i,j) is dist(rows[i], cols[j]).
+//It panics if rows and cols differ in length.
+func NewDistanceMatrix[P any](rows, cols []P, dist func(a, b P) float64) *FloatMatrix {
+	if len(rows) != len(cols) {
+		panic(fmt.Errorf("munkres: %d rows and %d columns: %w", len(rows), len(cols), ErrDimensionMismatch))
+	}
+	m := NewMatrix(int64(len(rows)))
+	for i, a := range rows {
+		for j, b := range cols {
+			m.SetElement(int64(i), int64(j), dist(a, b))
+		}
+	}
+	return m
+}
+
+//ToMaximization replaces every element v with max-v in place, where max is the largest element, and returns max.
+//Solving the result for minimum cost yields the assignment with the highest total of the original values.
+//Forbidden (+Inf) cells are skipped and stay forbidden.
+func (m *FloatMatrix) ToMaximization() float64 {
+	max := math.Inf(-1)
+	for _, v := range m.A {
+		if !math.IsInf(v, 1) && v > max {
+			max = v
+		}
+	}
+	for idx, v := range m.A {
+		if !math.IsInf(v, 1) {
+			m.A[idx] = max - v
+		}
+	}
+	return max
+}
+
+//GetElementOr returns the element of the matrix at position (i,j), or def if (i,j) lies outside the matrix
+func (m FloatMatrix) GetElementOr(i int64, j int64, def float64) float64 {
+	if i < 0 || j < 0 || i >= m.N || j >= m.N || i*m.N+j >= int64(len(m.A)) {
+		return def
+	}
+	return m.GetElement(i, j)
+}
+
+//Forbid marks cell (i,j) as forbidden by setting it to sentinel, which should be math.Inf(1)
+//or the value given to WithForbiddenSentinel
+func (m *FloatMatrix) Forbid(i, j int64, sentinel float64) error {
+	if i < 0 || j < 0 || i >= m.N || j >= m.N {
+		return fmt.Errorf("munkres: cell (%d,%d) of a matrix of size %d: %w", i, j, m.N, ErrOutOfRange)
+	}
+	m.SetElement(i, j, sentinel)
+	return nil
+}
+
+//NewMatrixFromPairs returns a new matrix with every element set to fill except the (row, col) keys of pairs,
+//which take their mapped values. It panics if a key lies outside the matrix.
+func NewMatrixFromPairs(n int64, pairs map[[2]int64]float64, fill float64) *FloatMatrix {
+	m := NewMatrix(n)
+	for idx := range m.A {
+		m.A[idx] = fill
+	}
+	for p, v := range pairs {
+		if p[0] < 0 || p[1] < 0 || p[0] >= n || p[1] >= n {
+			panic(fmt.Errorf("munkres: pair (%d,%d) of a matrix of size %d: %w", p[0], p[1], n, ErrOutOfRange))
+		}
+		m.SetElement(p[0], p[1], v)
+	}
+	return m
+}
+
+//AllEqual reports whether every element of the matrix is within tol of v
+func (m *FloatMatrix) AllEqual(v float64, tol float64) bool {
+	for _, x := range m.A {
+		if x != v && !(math.Abs(x-v) <= tol) {
+			return false
+		}
+	}
+	return true
+}
+
+//goStringRows is the largest matrix GoString prints in full; larger ones show only their leading rows and columns
+const goStringRows = 6
+
+//GoString renders the matrix for %#v. Matrices up to 6x6 print as a complete Go literal; larger ones print their
+//size and only the leading 6x6 block.
+func (m *FloatMatrix) GoString() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "&munkres.FloatMatrix{N: %d, A: []float64{", m.N)
+	if !m.isSquare() {
+		fmt.Fprintf(&b, "/* %d elements */}}", len(m.A))
+		return b.String()
+	}
+	shown := m.N
+	if shown > goStringRows {
+		shown = goStringRows
+		fmt.Fprintf(&b, "/* leading %dx%d of %dx%d */ ", shown, shown, m.N, m.N)
+	}
+	for i := zero64; i < shown; i++ {
+		for j := zero64; j < shown; j++ {
+			if i > 0 || j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(goFloat(m.GetElement(i, j)))
+		}
+	}
+	b.WriteString("}}")
+	return b.String()
+}
+
+//goFloat formats v as a Go expression that evaluates to exactly v
+func goFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "math.Inf(1)"
+	case math.IsInf(v, -1):
+		return "math.Inf(-1)"
+	case math.IsNaN(v):
+		return "math.NaN()"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+//AppendRowCol returns a new (N+1)x(N+1) matrix holding m with row appended at the bottom, col appended on the right
+//and corner at position (N,N). The matrix m itself is left unchanged; row and col must each hold N values.
+func (m *FloatMatrix) AppendRowCol(row, col []float64, corner float64) (*FloatMatrix, error) {
+	if int64(len(row)) != m.N || int64(len(col)) != m.N {
+		return nil, fmt.Errorf("munkres: appending a row of %d and a column of %d to a matrix of size %d: %w",
+			len(row), len(col), m.N, ErrDimensionMismatch)
+	}
+	n := m.N
+	grown := NewMatrix(n + 1)
+	for i := zero64; i < n; i++ {
+		copy(grown.A[i*(n+1):], m.A[i*n:(i+1)*n])
+		grown.SetElement(i, n, col[i])
+	}
+	copy(grown.A[n*(n+1):], row)
+	grown.SetElement(n, n, corner)
+	return grown, nil
+}
+
+//Hash returns a 64-bit FNV-1a hash of N and the bit patterns of A, so equal matrices always hash equally.
+//Because raw bits are hashed, 0 and -0 hash differently, as do NaNs with different payloads.
+func (m *FloatMatrix) Hash() uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(m.N))
+	h.Write(buf[:])
+	for _, v := range m.A {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		h.Write(buf[:])
+	}
+	return h.Sum64()
+}
+
+//NewMatrixFromFlat returns a FloatMatrix that adopts a, laid out row by row, as its backing slice without copying.
+//Later writes through either the slice or the matrix are visible to both. The length of a must be n*n.
+func NewMatrixFromFlat(n int64, a []float64) (*FloatMatrix, error) {
+	if n < 0 || int64(len(a)) != n*n {
+		return nil, fmt.Errorf("munkres: %d elements for a matrix of size %d: %w", len(a), n, ErrNotSquare)
+	}
+	return &FloatMatrix{N: n, A: a}, nil
+}
+
+//NewMatrixFromFlatCopy is like NewMatrixFromFlat but copies a, so the caller keeps ownership of the slice
+func NewMatrixFromFlatCopy(n int64, a []float64) (*FloatMatrix, error) {
+	m, err := NewMatrixFromFlat(n, a)
+	if err != nil {
+		return nil, err
+	}
+	m.A = append([]float64(nil), a...)
+	return m, nil
+}
+
+//GetElements returns the elements at the given (row, col) coordinates in order, failing on the first coordinate
+//outside the matrix
+func (m *FloatMatrix) GetElements(coords [][2]int64) ([]float64, error) {
+	vals := make([]float64, len(coords))
+	for k, c := range coords {
+		if c[0] < 0 || c[1] < 0 || c[0] >= m.N || c[1] >= m.N {
+			return nil, fmt.Errorf("munkres: coordinate %d (%d,%d) of a matrix of size %d: %w", k, c[0], c[1], m.N, ErrOutOfRange)
+		}
+		vals[k] = m.GetElement(c[0], c[1])
+	}
+	return vals, nil
+}
@@ -0,0 +1,475 @@
+package munkres
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+//equalFloats reports whether a and b hold the same values
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMulScalar(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, -3, math.Inf(1)}}
+	got := m.MulScalar(2)
+	if got.N != 2 || !equalFloats(got.A, []float64{2, 4, -6, math.Inf(1)}) {
+		t.Fatalf("MulScalar(2) = %v", got.A)
+	}
+	if !equalFloats(m.A, []float64{1, 2, -3, math.Inf(1)}) {
+		t.Fatal("MulScalar changed its receiver")
+	}
+}
+
+func TestRound(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1.234, 2.005000001, -0.126, math.Inf(1)}}
+	got := m.Round(2)
+	want := []float64{1.23, 2.01, -0.13, math.Inf(1)}
+	for idx := range want {
+		if got.A[idx] != want[idx] {
+			t.Fatalf("Round(2) = %v, want %v", got.A, want)
+		}
+	}
+	if m.A[0] != 1.234 {
+		t.Fatal("Round changed its receiver")
+	}
+}
+
+func TestRoundKeepsUnscalableValues(t *testing.T) {
+	m := &FloatMatrix{N: 1, A: []float64{1e307}}
+	if got := m.Round(2).A[0]; got != 1e307 {
+		t.Errorf("Round(2) of 1e307 = %v", got)
+	}
+	m.A[0] = 1.5
+	for _, decimals := range []int{400, -400} {
+		if got := m.Round(decimals).A[0]; got != 1.5 {
+			t.Errorf("Round(%d) of 1.5 = %v", decimals, got)
+		}
+	}
+}
+
+func TestNormalizeRows(t *testing.T) {
+	inf := math.Inf(1)
+	m := &FloatMatrix{N: 3, A: []float64{
+		1, 3, 4,
+		0, 0, 0,
+		2, inf, 6,
+	}}
+	m.NormalizeRows()
+	if want := []float64{0.125, 0.375, 0.5, 0, 0, 0, 0.25, inf, 0.75}; !equalFloats(m.A, want) {
+		t.Fatalf("NormalizeRows = %v, want %v", m.A, want)
+	}
+	for _, i := range []int64{0, 2} {
+		var sum float64
+		for j := int64(0); j < m.N; j++ {
+			if v := m.GetElement(i, j); !math.IsInf(v, 1) {
+				sum += v
+			}
+		}
+		if sum != 1 {
+			t.Fatalf("row %d sums to %v", i, sum)
+		}
+	}
+}
+
+func TestNormalizeMinMax(t *testing.T) {
+	inf := math.Inf(1)
+	m := &FloatMatrix{N: 2, A: []float64{2, 4, inf, 10}}
+	m.NormalizeMinMax()
+	if want := []float64{0, 0.25, inf, 1}; !equalFloats(m.A, want) {
+		t.Fatalf("NormalizeMinMax = %v, want %v", m.A, want)
+	}
+	m.A = []float64{3, 3, 3, inf}
+	m.NormalizeMinMax()
+	if want := []float64{0, 0, 0, inf}; !equalFloats(m.A, want) {
+		t.Fatalf("NormalizeMinMax of equal elements = %v, want %v", m.A, want)
+	}
+}
+
+func TestDiagonal(t *testing.T) {
+	m := NewMatrix(3)
+	if err := m.SetDiagonal([]float64{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.GetDiagonal(); !equalFloats(got, []float64{1, 2, 3}) {
+		t.Fatalf("GetDiagonal = %v", got)
+	}
+	if want := []float64{1, 0, 0, 0, 2, 0, 0, 0, 3}; !equalFloats(m.A, want) {
+		t.Fatalf("SetDiagonal wrote %v, want %v", m.A, want)
+	}
+	m.GetDiagonal()[0] = 9
+	if m.A[0] != 1 {
+		t.Fatal("GetDiagonal shares storage with the matrix")
+	}
+	if err := m.SetDiagonal([]float64{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("short diagonal: err = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestPermutationCost(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	if total, err := m.PermutationCost([]int64{0, 1, 2}); err != nil || total != 6 {
+		t.Fatalf("identity: %v, %v, want 6", total, err)
+	}
+	if total, err := m.PermutationCost([]int64{1, 0, 2}); err != nil || total != 5 {
+		t.Fatalf("swapped: %v, %v, want 5", total, err)
+	}
+	for _, perm := range [][]int64{{0, 0, 2}, {0, 1}, {0, 1, 3}, {-1, 1, 2}} {
+		if _, err := m.PermutationCost(perm); !errors.Is(err, ErrInvalidPermutation) {
+			t.Errorf("%v: err = %v, want ErrInvalidPermutation", perm, err)
+		}
+	}
+}
+
+func TestToRowsRoundTrip(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 3, math.Inf(1)}}
+	rows := m.ToRows()
+	if len(rows) != 2 || !equalFloats(rows[1], []float64{3, math.Inf(1)}) {
+		t.Fatalf("ToRows = %v", rows)
+	}
+	rows[0][0] = 9
+	if m.A[0] != 1 {
+		t.Fatal("ToRows shares storage with the matrix")
+	}
+	back, err := NewMatrixFromRows(m.ToRows())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.N != m.N || !equalFloats(back.A, m.A) {
+		t.Fatalf("round trip gave %v, want %v", back.A, m.A)
+	}
+	if _, err := NewMatrixFromRows([][]float64{{1, 2}, {3}}); !errors.Is(err, ErrNotSquare) {
+		t.Fatalf("ragged rows: err = %v, want ErrNotSquare", err)
+	}
+}
+
+func TestNewDistanceMatrix(t *testing.T) {
+	type point struct{ x, y float64 }
+	rows := []point{{0, 0}, {3, 4}}
+	cols := []point{{3, 0}, {0, 4}}
+	m := NewDistanceMatrix(rows, cols, func(a, b point) float64 {
+		return math.Hypot(a.x-b.x, a.y-b.y)
+	})
+	if want := []float64{3, 4, 4, 3}; m.N != 2 || !equalFloats(m.A, want) {
+		t.Fatalf("NewDistanceMatrix = %v, want %v", m.A, want)
+	}
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrDimensionMismatch) {
+			t.Fatalf("recovered %v, want ErrDimensionMismatch", err)
+		}
+	}()
+	NewDistanceMatrix(rows, cols[:1], func(a, b point) float64 { return 0 })
+}
+
+func TestToMaximization(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(6)))
+		transformed := &FloatMatrix{N: m.N, A: append([]float64(nil), m.A...)}
+		max := transformed.ToMaximization()
+		for idx, v := range transformed.A {
+			if v != max-m.A[idx] || v < 0 {
+				t.Fatalf("element %d became %v from %v with max %v", idx, v, m.A[idx], max)
+			}
+		}
+		_, total, err := Solve(transformed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//every assignment has N cells, so the smallest transformed total is N*max less the largest original total
+		want := -bruteForceMin(m.MulScalar(-1))
+		if got := float64(m.N)*max - total; got != want {
+			t.Fatalf("trial %d: maximum %v, want %v", trial, got, want)
+		}
+		if got := GetMunkresMaxScore(m); got != want {
+			t.Fatalf("trial %d: GetMunkresMaxScore = %v, want %v", trial, got, want)
+		}
+	}
+	m := &FloatMatrix{N: 2, A: []float64{1, math.Inf(1), 3, 2}}
+	if max := m.ToMaximization(); max != 3 || !equalFloats(m.A, []float64{2, math.Inf(1), 0, 1}) {
+		t.Fatalf("ToMaximization returned %v and left %v", max, m.A)
+	}
+}
+
+func TestGetElementOr(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 3, 4}}
+	if got := m.GetElementOr(1, 0, -1); got != 3 {
+		t.Fatalf("GetElementOr(1, 0) = %v, want 3", got)
+	}
+	for _, ij := range [][2]int64{{-1, 0}, {0, -1}, {2, 0}, {0, 2}} {
+		if got := m.GetElementOr(ij[0], ij[1], -1); got != -1 {
+			t.Errorf("GetElementOr(%d, %d) = %v, want the default", ij[0], ij[1], got)
+		}
+	}
+	short := &FloatMatrix{N: 2, A: []float64{1, 2, 3}}
+	if got := short.GetElementOr(1, 1, -1); got != -1 {
+		t.Fatalf("element past the end of A = %v, want the default", got)
+	}
+}
+
+func TestForbid(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	//(1,1) is part of the unconstrained optimum
+	if err := m.Forbid(1, 1, math.Inf(1)); err != nil {
+		t.Fatal(err)
+	}
+	result, _, err := Solve(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result[1].Col == 1 {
+		t.Fatalf("solver chose the forbidden cell: %v", result)
+	}
+	if err := m.Forbid(0, 1, 100); err != nil || m.GetElement(0, 1) != 100 {
+		t.Fatalf("sentinel not written: %v, %v", m.GetElement(0, 1), err)
+	}
+	if result, _, _ := NewSolver(WithForbiddenSentinel(100)).Solve(m); result[0].Col == 1 {
+		t.Fatalf("solver chose the cell forbidden by sentinel: %v", result)
+	}
+	for _, ij := range [][2]int64{{-1, 0}, {0, 3}} {
+		if err := m.Forbid(ij[0], ij[1], math.Inf(1)); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Forbid(%d, %d): err = %v, want ErrOutOfRange", ij[0], ij[1], err)
+		}
+	}
+}
+
+func TestNewMatrixFromPairs(t *testing.T) {
+	inf := math.Inf(1)
+	m := NewMatrixFromPairs(3, map[[2]int64]float64{{0, 1}: 4, {1, 2}: 5, {2, 0}: 6}, inf)
+	if want := []float64{inf, 4, inf, inf, inf, 5, 6, inf, inf}; m.N != 3 || !equalFloats(m.A, want) {
+		t.Fatalf("NewMatrixFromPairs = %v, want %v", m.A, want)
+	}
+	result, total, err := Solve(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 15 || result[0].Col != 1 || result[1].Col != 2 || result[2].Col != 0 {
+		t.Fatalf("solving the sparse matrix gave %v totalling %v", result, total)
+	}
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("recovered %v, want ErrOutOfRange", err)
+		}
+	}()
+	NewMatrixFromPairs(2, map[[2]int64]float64{{2, 0}: 1}, 0)
+}
+
+func TestAllEqual(t *testing.T) {
+	m := NewMatrix(3)
+	if !m.AllEqual(0, 0) {
+		t.Fatal("all-zero matrix not reported equal to 0")
+	}
+	m.SetElement(2, 1, 0.5)
+	if m.AllEqual(0, 0) || m.AllEqual(0, 0.4) {
+		t.Fatal("matrix with a cell of 0.5 reported equal to 0")
+	}
+	if !m.AllEqual(0, 0.5) {
+		t.Fatal("cell within tol reported different")
+	}
+	m.SetElement(2, 1, math.Inf(1))
+	if m.AllEqual(0, 1e300) {
+		t.Fatal("forbidden cell reported equal to 0")
+	}
+	for idx := range m.A {
+		m.A[idx] = math.Inf(1)
+	}
+	if !m.AllEqual(math.Inf(1), 0) {
+		t.Fatal("all-forbidden matrix not reported equal to +Inf")
+	}
+}
+
+func TestGoString(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2.5, math.Inf(1), -3}}
+	if got, want := fmt.Sprintf("%#v", m), "&munkres.FloatMatrix{N: 2, A: []float64{1, 2.5, math.Inf(1), -3}}"; got != want {
+		t.Fatalf("%%#v = %s, want %s", got, want)
+	}
+	big := NewMatrix(10)
+	big.SetElement(0, 1, 7)
+	big.SetElement(9, 9, 8)
+	got := big.GoString()
+	if !strings.Contains(got, "N: 10") || !strings.Contains(got, "leading 6x6 of 10x10") || !strings.Contains(got, "0, 7, 0") {
+		t.Fatalf("GoString of a 10x10 matrix = %s", got)
+	}
+	if strings.Contains(got, "8") {
+		t.Fatalf("GoString of a 10x10 matrix shows a cell outside the leading block: %s", got)
+	}
+	if got := (&FloatMatrix{N: 2, A: []float64{1}}).GoString(); !strings.Contains(got, "1 elements") {
+		t.Fatalf("GoString of an inconsistent matrix = %s", got)
+	}
+}
+
+func TestAppendRowCol(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 3, 4}}
+	grown, err := m.AppendRowCol([]float64{5, 6}, []float64{7, 8}, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{1, 2, 7, 3, 4, 8, 5, 6, 9}; grown.N != 3 || !equalFloats(grown.A, want) {
+		t.Fatalf("AppendRowCol = %v, want %v", grown.A, want)
+	}
+	if m.N != 2 || !equalFloats(m.A, []float64{1, 2, 3, 4}) {
+		t.Fatal("AppendRowCol changed its receiver")
+	}
+	//matching the new row to the new column beats every pairing that breaks up the old 2x2 optimum of 5
+	if _, total, err := Solve(grown); err != nil || total != 1+4+9 {
+		t.Fatalf("solving the grown matrix gave %v, %v, want 14", total, err)
+	}
+	if _, err := m.AppendRowCol([]float64{5}, []float64{7, 8}, 9); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("short row: err = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := &FloatMatrix{N: 2, A: []float64{1, 2, 3, math.Inf(1)}}
+	b := &FloatMatrix{N: 2, A: []float64{1, 2, 3, math.Inf(1)}}
+	if a.Hash() != b.Hash() {
+		t.Fatal("equal matrices hash differently")
+	}
+	b.A[2] = 3.0000001
+	if a.Hash() == b.Hash() {
+		t.Fatal("a single changed cell left the hash unchanged")
+	}
+	b.A[2] = 3
+	b.A[0] = math.Copysign(0, -1)
+	a.A[0] = 0
+	if a.Hash() == b.Hash() {
+		t.Fatal("0 and -0 hash equally")
+	}
+	if NewMatrix(0).Hash() == (&FloatMatrix{N: 1}).Hash() {
+		t.Fatal("N is not part of the hash")
+	}
+}
+
+func TestNewMatrixFromFlat(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	m, err := NewMatrixFromFlat(2, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the slice is adopted, not copied
+	a[0] = 9
+	if m.GetElement(0, 0) != 9 {
+		t.Fatal("write through the slice not seen by the matrix")
+	}
+	m.SetElement(1, 1, 8)
+	if a[3] != 8 {
+		t.Fatal("write through the matrix not seen by the slice")
+	}
+	for _, c := range []struct {
+		n int64
+		a []float64
+	}{{2, make([]float64, 3)}, {-1, nil}, {1, make([]float64, 4)}} {
+		if _, err := NewMatrixFromFlat(c.n, c.a); !errors.Is(err, ErrNotSquare) {
+			t.Errorf("n=%d with %d elements: err = %v, want ErrNotSquare", c.n, len(c.a), err)
+		}
+	}
+}
+
+func TestGetElements(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 3, 4}}
+	got, err := m.GetElements([][2]int64{{1, 1}, {0, 1}, {1, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalFloats(got, []float64{4, 2, 4}) {
+		t.Fatalf("GetElements = %v, want [4 2 4]", got)
+	}
+	if got, err := m.GetElements([][2]int64{{0, 0}, {2, 0}}); got != nil || !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("out of range coordinate: %v, %v, want ErrOutOfRange", got, err)
+	}
+}
+
+func TestNewSymmetricMatrixFromUpper(t *testing.T) {
+	m, err := NewSymmetricMatrixFromUpper(3, []float64{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{1, 2, 3, 2, 4, 5, 3, 5, 6}; m.N != 3 || !equalFloats(m.A, want) {
+		t.Fatalf("NewSymmetricMatrixFromUpper = %v, want %v", m.A, want)
+	}
+	for i := int64(0); i < m.N; i++ {
+		for j := int64(0); j < m.N; j++ {
+			if m.GetElement(i, j) != m.GetElement(j, i) {
+				t.Fatalf("(%d,%d) and (%d,%d) differ", i, j, j, i)
+			}
+		}
+	}
+	if _, err := NewSymmetricMatrixFromUpper(3, make([]float64, 5)); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("short triangle: err = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestNewMatrixFromNestedMap(t *testing.T) {
+	costs := map[string]map[string]float64{
+		"b": {"x": 1, "y": 2},
+		"a": {"y": 3},
+	}
+	m, rows, cols, err := NewMatrixFromNestedMap(nil, nil, costs, math.Inf(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(rows) != "[a b]" || fmt.Sprint(cols) != "[x y]" {
+		t.Fatalf("keys = %v, %v, want [a b], [x y]", rows, cols)
+	}
+	if want := []float64{math.Inf(1), 3, 1, 2}; !equalFloats(m.A, want) {
+		t.Fatalf("NewMatrixFromNestedMap = %v, want %v", m.A, want)
+	}
+	m, _, _, err = NewMatrixFromNestedMap([]string{"b", "a"}, []string{"y", "x"}, costs, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{2, 1, 3, 9}; !equalFloats(m.A, want) {
+		t.Fatalf("explicit keys = %v, want %v", m.A, want)
+	}
+	if _, _, _, err := NewMatrixFromNestedMap([]string{"a"}, []string{"x", "y"}, costs, 0); !errors.Is(err, ErrNotSquare) {
+		t.Fatalf("mismatched keys: err = %v, want ErrNotSquare", err)
+	}
+	if _, _, _, err := NewMatrixFromNestedMap([]string{"a", "a"}, []string{"x", "y"}, costs, 0); err == nil {
+		t.Fatal("duplicate row key accepted")
+	}
+}
+
+func TestData(t *testing.T) {
+	m := NewMatrix(2)
+	m.A = []float64{1, 2, 3, 4}
+	data := m.Data()
+	if !equalFloats(data, m.A) {
+		t.Fatalf("Data = %v, want %v", data, m.A)
+	}
+	data[0] = 99
+	if m.GetElement(0, 0) != 1 {
+		t.Fatalf("changing the copy changed the matrix to %v", m.A)
+	}
+	m.SetElement(1, 1, 7)
+	if data[3] != 4 {
+		t.Fatalf("changing the matrix changed the copy to %v", data)
+	}
+}
+
+func TestDynamicRange(t *testing.T) {
+	wide := &FloatMatrix{N: 2, A: []float64{1e-6, 0, -1e6, math.Inf(1)}}
+	if got := wide.DynamicRange(); math.Abs(got-1e12) > 1 || got <= DynamicRangeWarning {
+		t.Fatalf("DynamicRange of 1e-6 to 1e6 = %v, want 1e12 above the warning threshold", got)
+	}
+	narrow := &FloatMatrix{N: 2, A: []float64{2, 4, -8, 1}}
+	if got := narrow.DynamicRange(); got != 8 || got > DynamicRangeWarning {
+		t.Fatalf("DynamicRange of 1 to 8 = %v, want 8", got)
+	}
+	empty := &FloatMatrix{N: 2, A: []float64{0, math.Inf(1), 0, 0}}
+	if got := empty.DynamicRange(); got != 1 {
+		t.Fatalf("DynamicRange with no nonzero finite element = %v, want 1", got)
+	}
+}
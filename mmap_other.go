@@ -0,0 +1,25 @@
+//go:build !unix
+
+package munkres
+
+import "errors"
+
+//MmapMatrix is a read-only CostSource backed by a memory-mapped file. Memory mapping is only supported on unix.
+type MmapMatrix struct {
+	n int64
+}
+
+//OpenMatrixMmap is not supported on this platform and always returns an error
+func OpenMatrixMmap(path string) (*MmapMatrix, func() error, error) {
+	return nil, nil, errors.New("munkres: memory-mapped matrices are not supported on this platform")
+}
+
+//Size returns N, the number of rows and columns
+func (m *MmapMatrix) Size() int64 {
+	return m.n
+}
+
+//Cost returns the cost at row i, column j
+func (m *MmapMatrix) Cost(i, j int64) float64 {
+	panic("munkres: memory-mapped matrices are not supported on this platform")
+}
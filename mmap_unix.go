@@ -0,0 +1,65 @@
+//go:build unix
+
+package munkres
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"syscall"
+)
+
+//MmapMatrix is a read-only CostSource backed by a memory-mapped file in the binary format written by WriteBinary.
+//Cells are read straight from the mapping, so the file itself is never loaded onto the heap. Solving it with
+//SolveSource still builds the N by N working matrix the algorithm reduces, which is on the heap.
+type MmapMatrix struct {
+	n    int64
+	data []byte
+}
+
+//OpenMatrixMmap maps the binary matrix file at path read-only. The returned function unmaps the file and must be
+//called once the matrix is no longer used; reading the matrix after that faults.
+func OpenMatrixMmap(path string) (*MmapMatrix, func() error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	size := info.Size()
+	if size < int64(binaryHeaderSize) || size > math.MaxInt {
+		return nil, nil, ErrBadBinaryHeader
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	unmap := func() error {
+		return syscall.Munmap(data)
+	}
+	//parseBinaryHeader bounds n so that the expected length cannot overflow
+	n, err := parseBinaryHeader(data)
+	if want := int64(binaryHeaderSize) + 8*n*n; err == nil && size != want {
+		err = fmt.Errorf("munkres: %s holds %d bytes, expected %d for size %d", path, size, want, n)
+	}
+	if err != nil {
+		unmap()
+		return nil, nil, err
+	}
+	return &MmapMatrix{n: n, data: data}, unmap, nil
+}
+
+//Size returns N, the number of rows and columns
+func (m *MmapMatrix) Size() int64 {
+	return m.n
+}
+
+//Cost returns the cost at row i, column j
+func (m *MmapMatrix) Cost(i, j int64) float64 {
+	offset := int64(binaryHeaderSize) + 8*(i*m.n+j)
+	return math.Float64frombits(binary.LittleEndian.Uint64(m.data[offset : offset+8]))
+}
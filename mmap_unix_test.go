@@ -0,0 +1,51 @@
+//go:build unix
+
+package munkres
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenMatrixMmapSolves(t *testing.T) {
+	m := randomMatrix(rand.New(rand.NewSource(5)), 6)
+	path := filepath.Join(t.TempDir(), "m.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteBinary(f, m); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	mm, unmap, err := OpenMatrixMmap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unmap()
+	if mm.Size() != m.N || mm.Cost(2, 3) != m.GetElement(2, 3) {
+		t.Fatalf("mapped size %d, cost(2,3) %v", mm.Size(), mm.Cost(2, 3))
+	}
+	_, total, err := SolveSource(mm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := GetMunkresMinScore(m); total != want {
+		t.Fatalf("total = %v, want %v", total, want)
+	}
+}
+
+func TestOpenMatrixMmapRejectsWrongLength(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "m.bin")
+	//announces the largest accepted size, whose expected length only fits an int64 because of the header bound
+	if err := os.WriteFile(path, append(binaryHeader(maxBinarySize), make([]byte, 8)...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := OpenMatrixMmap(path); err == nil {
+		t.Fatal("file of the wrong length was accepted")
+	}
+}
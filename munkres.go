@@ -56,6 +56,18 @@ const (
 
 type mark int
 
+//ReductionOrder selects which reductions step 1 applies before the first zeros are starred
+type ReductionOrder int
+
+const (
+	//RowsFirst subtracts every row's minimum, the classic initialization
+	RowsFirst ReductionOrder = iota
+	//ColumnsFirst subtracts every column's minimum instead
+	ColumnsFirst
+	//Both subtracts row minima and then column minima
+	Both
+)
+
 type context struct {
 	m          *FloatMatrix
 	rowCovered []bool
@@ -63,8 +75,34 @@ type context struct {
 	marked     []mark
 	z0row      int64
 	z0column   int64
+	rowDual    []float64
+	colDual    []float64
 	rowPath    []int64
 	colPath    []int64
+	stop       func(next step) bool
+	next       step
+	onStep     func(step)
+	onPath     func(rows, cols []int64)
+	warm       [][2]int64
+	prefs      [][]int64
+	undo       *undoLog
+	floor      float64
+	hasFloor   bool
+	reduction  ReductionOrder
+	lessFn     func(a, b float64) bool
+	isZeroFn   func(float64) bool
+	stats      SolveStats
+	err        error
+}
+
+//SolveStats summarizes the work done by a solve
+type SolveStats struct {
+	//StepCounts holds how many times each step ran, indexed by step number minus one
+	StepCounts [6]int
+	//InitialStarComplete is true when the zeros starred by step 2 already formed a complete assignment
+	InitialStarComplete bool
+	//Step6Zeros holds, for each run of step 6, how many uncovered cells it reduced to zero
+	Step6Zeros []int
 }
 
 type step interface {
@@ -78,25 +116,96 @@ type step4 struct{}
 type step5 struct{}
 type step6 struct{}
 
+//newContext panics with ErrNotSquare if A does not hold N*N elements, since the steps index A by N alone
 func newContext(m *FloatMatrix) *context {
+	if !m.isSquare() {
+		panic(ErrNotSquare)
+	}
+	return newContextOwning(&FloatMatrix{A: append([]float64(nil), m.A...), N: m.N})
+}
+
+//newContextOwning is like newContext but reduces m itself, for callers that built m only to be solved
+func newContextOwning(m *FloatMatrix) *context {
+	if !m.isSquare() {
+		panic(ErrNotSquare)
+	}
 	ctx := context{
-		m: &FloatMatrix{
-			A: make([]float64, m.N*m.N),
-			N: m.N,
-		},
+		m:       m,
 		rowPath: make([]int64, 2*m.N),
 		colPath: make([]int64, 2*m.N),
 		marked:  make([]mark, m.N*m.N),
+		rowDual: make([]float64, m.N),
+		colDual: make([]float64, m.N),
 	}
-	copy(ctx.m.A, m.A)
 	clearCovers(&ctx)
 	return &ctx
 }
 
-func min(a ...float64) float64 {
-	min := math.MaxFloat64
+//undoLog remembers the original value of every element changed while solving a matrix in place
+type undoLog struct {
+	touched []bool
+	pos     []int64
+	orig    []float64
+}
+
+//newContextInPlace is like newContext but works directly on m.A, logging changes so restore can undo them
+func newContextInPlace(m *FloatMatrix) *context {
+	if !m.isSquare() {
+		panic(ErrNotSquare)
+	}
+	ctx := context{
+		m:       m,
+		rowPath: make([]int64, 2*m.N),
+		colPath: make([]int64, 2*m.N),
+		marked:  make([]mark, m.N*m.N),
+		rowDual: make([]float64, m.N),
+		colDual: make([]float64, m.N),
+		undo:    &undoLog{touched: make([]bool, m.N*m.N)},
+	}
+	clearCovers(&ctx)
+	return &ctx
+}
+
+//adjust adds delta to the working element at pos, logging its original value first when solving in place
+func (ctx *context) adjust(pos int64, delta float64) {
+	if delta == 0 {
+		return
+	}
+	if ctx.undo != nil && !ctx.undo.touched[pos] {
+		ctx.undo.touched[pos] = true
+		ctx.undo.pos = append(ctx.undo.pos, pos)
+		ctx.undo.orig = append(ctx.undo.orig, ctx.m.A[pos])
+	}
+	ctx.m.A[pos] += delta
+}
+
+//restore puts back the original values of every element changed by an in-place solve
+func (ctx *context) restore() {
+	for k, pos := range ctx.undo.pos {
+		ctx.m.A[pos] = ctx.undo.orig[k]
+	}
+}
+
+//less orders two costs, using the comparator given to WithComparator if there is one
+func (ctx *context) less(a, b float64) bool {
+	if ctx.lessFn != nil {
+		return ctx.lessFn(a, b)
+	}
+	return a < b
+}
+
+//zero reports whether a reduced cost counts as zero, using the predicate given to WithComparator if there is one
+func (ctx *context) zero(v float64) bool {
+	if ctx.isZeroFn != nil {
+		return ctx.isZeroFn(v)
+	}
+	return v == 0
+}
+
+func (ctx *context) min(a ...float64) float64 {
+	min := math.Inf(1)
 	for _, i := range a {
-		if i < min {
+		if ctx.less(i, min) {
 			min = i
 		}
 	}
@@ -104,15 +213,57 @@ func min(a ...float64) float64 {
 }
 
 func (step1) compute(ctx *context) (step, bool) {
+	if ctx.reduction != ColumnsFirst {
+		if !reduceRows(ctx) {
+			ctx.err = ErrInfeasible
+			return nil, true
+		}
+	}
+	if ctx.reduction != RowsFirst {
+		if !reduceColumns(ctx) {
+			ctx.err = ErrInfeasible
+			return nil, true
+		}
+	}
+	return step2{}, false
+}
+
+//reduceRows subtracts each row's minimum from the row, reporting false if a row has no allowed cell
+func reduceRows(ctx *context) bool {
 	n := ctx.m.N
 	for i := zero64; i < n; i++ {
 		row := ctx.m.A[i*n : (i+1)*n]
-		minval := min(row...)
+		minval := ctx.min(row...)
+		if math.IsInf(minval, 1) {
+			return false
+		}
 		for idx := range row {
-			row[idx] -= minval
+			ctx.adjust(i*n+int64(idx), -minval)
 		}
+		ctx.rowDual[i] += minval
 	}
-	return step2{}, false
+	return true
+}
+
+//reduceColumns subtracts each column's minimum from the column, reporting false if a column has no allowed cell
+func reduceColumns(ctx *context) bool {
+	n := ctx.m.N
+	for j := zero64; j < n; j++ {
+		minval := math.Inf(1)
+		for i := zero64; i < n; i++ {
+			if a := ctx.m.A[i*n+j]; ctx.less(a, minval) {
+				minval = a
+			}
+		}
+		if math.IsInf(minval, 1) {
+			return false
+		}
+		for i := zero64; i < n; i++ {
+			ctx.adjust(i*n+j, -minval)
+		}
+		ctx.colDual[j] += minval
+	}
+	return true
 }
 
 func clearCovers(ctx *context) {
@@ -123,22 +274,39 @@ func clearCovers(ctx *context) {
 
 func (step2) compute(ctx *context) (step, bool) {
 	n := ctx.m.N
+	for _, p := range ctx.warm {
+		i, j := p[0], p[1]
+		if pos := i*n + j; ctx.zero(ctx.m.A[pos]) {
+			ctx.marked[pos] = Starred
+			ctx.colCovered[j] = true
+			ctx.rowCovered[i] = true
+		}
+	}
 	for i := zero64; i < n; i++ {
-		rowStart := i * n
-		for j := zero64; j < n; j++ {
-			pos := rowStart + j
-			if (ctx.m.A[pos] == 0) &&
-				!ctx.colCovered[j] && !ctx.rowCovered[i] {
-				ctx.marked[pos] = Starred
-				ctx.colCovered[j] = true
-				ctx.rowCovered[i] = true
+		if int64(len(ctx.prefs)) > i {
+			for _, j := range ctx.prefs[i] {
+				starIfFree(ctx, i, j)
 			}
 		}
+		for j := zero64; j < n; j++ {
+			starIfFree(ctx, i, j)
+		}
 	}
 	clearCovers(ctx)
 	return step3{}, false
 }
 
+//starIfFree stars (i,j) if it is zero and neither its row nor its column holds a star yet
+func starIfFree(ctx *context, i, j int64) {
+	pos := i*ctx.m.N + j
+	if ctx.zero(ctx.m.A[pos]) &&
+		!ctx.colCovered[j] && !ctx.rowCovered[i] {
+		ctx.marked[pos] = Starred
+		ctx.colCovered[j] = true
+		ctx.rowCovered[i] = true
+	}
+}
+
 func (step3) compute(ctx *context) (step, bool) {
 	n := ctx.m.N
 	count := zero64
@@ -153,6 +321,7 @@ func (step3) compute(ctx *context) (step, bool) {
 		}
 	}
 	if count >= n {
+		ctx.stats.InitialStarComplete = ctx.stats.StepCounts[4] == 0
 		return nil, true
 	}
 
@@ -167,7 +336,7 @@ Loop:
 	for i := zero64; i < n; i++ {
 		rowStart := i * n
 		for j := zero64; j < n; j++ {
-			if (ctx.m.A[rowStart+j] == 0) &&
+			if ctx.zero(ctx.m.A[rowStart+j]) &&
 				!ctx.rowCovered[i] && !ctx.colCovered[j] {
 				row = i
 				col = j
@@ -279,6 +448,9 @@ func (step5) compute(ctx *context) (step, bool) {
 			ctx.colPath[count] = col
 		}
 	}
+	if ctx.onPath != nil {
+		ctx.onPath(ctx.rowPath[:count+1], ctx.colPath[:count+1])
+	}
 	convertPath(ctx, count)
 	clearCovers(ctx)
 	erasePrimes(ctx)
@@ -287,13 +459,13 @@ func (step5) compute(ctx *context) (step, bool) {
 
 func findSmallest(ctx *context) float64 {
 	n := ctx.m.N
-	minval := math.MaxFloat64
+	minval := math.Inf(1)
 	for i := zero64; i < n; i++ {
 		rowStart := i * n
 		for j := zero64; j < n; j++ {
 			if (!ctx.rowCovered[i]) && (!ctx.colCovered[j]) {
 				a := ctx.m.A[rowStart+j]
-				if minval > a {
+				if ctx.less(a, minval) {
 					minval = a
 				}
 			}
@@ -305,39 +477,164 @@ func findSmallest(ctx *context) float64 {
 func (step6) compute(ctx *context) (step, bool) {
 	n := ctx.m.N
 	minval := findSmallest(ctx)
+	if math.IsInf(minval, 1) {
+		ctx.err = ErrInfeasible
+		return nil, true
+	}
+	created := 0
 	for i := zero64; i < n; i++ {
 		rowStart := i * n
 		for j := zero64; j < n; j++ {
 			if ctx.rowCovered[i] {
-				ctx.m.A[rowStart+j] += minval
+				ctx.adjust(rowStart+j, minval)
 			}
 			if !ctx.colCovered[j] {
-				ctx.m.A[rowStart+j] -= minval
+				ctx.adjust(rowStart+j, -minval)
+				if !ctx.rowCovered[i] && ctx.zero(ctx.m.A[rowStart+j]) {
+					created++
+				}
 			}
+			//exact arithmetic never takes a reduced cost below zero, so a clamp only absorbs rounding error
+			if ctx.hasFloor && ctx.less(ctx.m.A[rowStart+j], ctx.floor) {
+				ctx.adjust(rowStart+j, ctx.floor-ctx.m.A[rowStart+j])
+			}
+		}
+	}
+	for k := zero64; k < n; k++ {
+		if ctx.rowCovered[k] {
+			ctx.rowDual[k] -= minval
+		}
+		if !ctx.colCovered[k] {
+			ctx.colDual[k] += minval
 		}
 	}
+	ctx.stats.Step6Zeros = append(ctx.stats.Step6Zeros, created)
 	return step4{}, false
 }
 
-//GetMunkresMinScore returns the sum of the elements that comprise the lowest cost path
-func GetMunkresMinScore(m *FloatMatrix) float64 {
-	ctx := newContext(m)
-
-	var stp step
-	stp = step1{}
+//run executes the steps until the solve completes or fails. A run ended by the stop function remembers the step it
+//was about to begin, so calling run again resumes from there.
+func (ctx *context) run() error {
+	stp := ctx.next
+	if stp == nil {
+		stp = step1{}
+	}
+	ctx.next, ctx.err = nil, nil
 	for {
+		if ctx.stop != nil && ctx.stop(stp) {
+			ctx.err = errStopped
+			ctx.next = stp
+			break
+		}
 		nextStep, done := stp.compute(ctx)
+		ctx.stats.StepCounts[stepNumber(stp)-1]++
+		if ctx.onStep != nil {
+			ctx.onStep(stp)
+		}
 
 		if done {
 			break
 		}
 		stp = nextStep
 	}
+	if debugChecks && ctx.err == nil {
+		if err := checkOptimality(ctx); err != nil {
+			panic(err)
+		}
+	}
+	return ctx.err
+}
+
+//dualValue returns the sum of the row and column potentials, the total subtracted from every complete assignment so far.
+//It is a lower bound on the optimal total that rises with every step 6 and reaches the optimum when the solve
+//completes.
+func (ctx *context) dualValue() float64 {
+	var total float64
+	for k := range ctx.rowDual {
+		total += ctx.rowDual[k] + ctx.colDual[k]
+	}
+	return total
+}
+
+func stepNumber(stp step) int {
+	switch stp.(type) {
+	case step1:
+		return 1
+	case step2:
+		return 2
+	case step3:
+		return 3
+	case step4:
+		return 4
+	case step5:
+		return 5
+	case step6:
+		return 6
+	}
+	return 0
+}
 
+//assignment returns the starred column of every row, or -1 for rows without a star
+func (ctx *context) assignment() []int64 {
+	n := ctx.m.N
+	perm := make([]int64, n)
+	for i := range perm {
+		perm[i] = -1
+	}
+	for pos, markedVal := range ctx.marked {
+		if markedVal == Starred {
+			perm[int64(pos)/n] = int64(pos) % n
+		}
+	}
+	return perm
+}
+
+//starredPositions returns the flat positions in A of the starred cells in ascending order
+func (ctx *context) starredPositions() []int {
+	positions := make([]int, 0, ctx.m.N)
+	for pos, markedVal := range ctx.marked {
+		if markedVal == Starred {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+//score sums the elements of m at the starred positions
+func (ctx *context) score(m *FloatMatrix) float64 {
 	var sumMinCost float64
 	for markedIdx, markedVal := range ctx.marked {
-		sumMinCost += float64(markedVal) * m.A[markedIdx]
+		if markedVal == Starred {
+			sumMinCost += m.A[markedIdx]
+		}
 	}
-
 	return sumMinCost
 }
+
+//GetMunkresMinScore returns the sum of the elements that comprise the lowest cost path.
+//Cells set to +Inf are forbidden; if no path avoids them the result is +Inf.
+func GetMunkresMinScore(m *FloatMatrix) float64 {
+	ctx := newContext(m)
+	if ctx.run() != nil {
+		return math.Inf(1)
+	}
+	return ctx.score(m)
+}
+
+//GetMunkresMinScoreNoCopy returns the same result as GetMunkresMinScore without copying m first.
+//The solver reduces m.A in place and restores every changed element before returning, so m is unchanged afterwards
+//but must not be read or written concurrently. Only changed elements are logged, so rows that already contain a zero
+//and few reductions keep the log small; dense matrices usually change almost every element and gain little.
+func GetMunkresMinScoreNoCopy(m *FloatMatrix) float64 {
+	ctx := newContextInPlace(m)
+	//restoring in a deferred call keeps m intact even if the run panics, as the munkres_checks tag makes it do on a
+	//failed optimality check
+	err := func() error {
+		defer ctx.restore()
+		return ctx.run()
+	}()
+	if err != nil {
+		return math.Inf(1)
+	}
+	return ctx.score(m)
+}
@@ -0,0 +1,149 @@
+package munkres
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//randomMatrix returns an n by n matrix of small random integer costs, which keeps sums exact
+func randomMatrix(r *rand.Rand, n int64) *FloatMatrix {
+	m := NewMatrix(n)
+	for idx := range m.A {
+		m.A[idx] = float64(r.Intn(50))
+	}
+	return m
+}
+
+//bruteForceMin returns the lowest total over every permutation of m, or +Inf if each one uses a forbidden cell
+func bruteForceMin(m *FloatMatrix) float64 {
+	perm := make([]int64, m.N)
+	for i := range perm {
+		perm[i] = int64(i)
+	}
+	best := math.Inf(1)
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(perm) {
+			var total float64
+			for i, j := range perm {
+				total += m.GetElement(int64(i), j)
+			}
+			best = math.Min(best, total)
+			return
+		}
+		for x := k; x < len(perm); x++ {
+			perm[k], perm[x] = perm[x], perm[k]
+			permute(k + 1)
+			perm[k], perm[x] = perm[x], perm[k]
+		}
+	}
+	permute(0)
+	return best
+}
+
+func TestGetMunkresMinScoreMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(6)))
+		if got, want := GetMunkresMinScore(m), bruteForceMin(m); got != want {
+			t.Fatalf("trial %d: GetMunkresMinScore = %v, want %v", trial, got, want)
+		}
+	}
+}
+
+//permuteAndSolve solves the matrix whose cell (i,j) is m's cell (rowPerm[i], colPerm[j]) and maps the pairs back to
+//m's indices, so the result can be compared with a solve of m itself
+func permuteAndSolve(t *testing.T, m *FloatMatrix, rowPerm, colPerm []int64) ([]Assignment, float64) {
+	t.Helper()
+	p := NewMatrix(m.N)
+	for i := zero64; i < m.N; i++ {
+		for j := zero64; j < m.N; j++ {
+			p.SetElement(i, j, m.GetElement(rowPerm[i], colPerm[j]))
+		}
+	}
+	result, total, err := Solve(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range result {
+		result[k].Row, result[k].Col = rowPerm[result[k].Row], colPerm[result[k].Col]
+	}
+	return result, total
+}
+
+func TestOptimumInvariantUnderRelabeling(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for trial := 0; trial < 100; trial++ {
+		n := int64(1 + r.Intn(8))
+		m := randomMatrix(r, n)
+		if trial%3 == 0 && n > 1 {
+			m.A[r.Intn(len(m.A))] = math.Inf(1)
+		}
+		want := GetMunkresMinScore(m)
+		rowPerm := make([]int64, n)
+		colPerm := make([]int64, n)
+		for k, v := range r.Perm(int(n)) {
+			rowPerm[k] = int64(v)
+		}
+		for k, v := range r.Perm(int(n)) {
+			colPerm[k] = int64(v)
+		}
+		result, total := permuteAndSolve(t, m, rowPerm, colPerm)
+		if total != want {
+			t.Fatalf("trial %d: relabeled optimum %v, original %v", trial, total, want)
+		}
+		perm := make([]int64, n)
+		for _, a := range result {
+			perm[a.Row] = a.Col
+		}
+		if cost, err := m.PermutationCost(perm); err != nil || cost != want {
+			t.Fatalf("trial %d: mapped assignment %v costs %v (%v) in the original, want %v", trial, perm, cost, err, want)
+		}
+	}
+}
+
+func TestInconsistentMatrixRejected(t *testing.T) {
+	for _, m := range []*FloatMatrix{{N: 3, A: make([]float64, 4)}, {N: 2, A: make([]float64, 9)}, {N: -1}} {
+		if m.isSquare() {
+			t.Fatalf("N=%d with %d elements reported square", m.N, len(m.A))
+		}
+		if _, _, err := Solve(m); !errors.Is(err, ErrNotSquare) {
+			t.Fatalf("N=%d with %d elements: Solve err = %v, want ErrNotSquare", m.N, len(m.A), err)
+		}
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrNotSquare) {
+					t.Fatalf("N=%d with %d elements: recovered %v, want ErrNotSquare", m.N, len(m.A), err)
+				}
+			}()
+			GetMunkresMinScore(m)
+		}()
+	}
+	if !NewMatrix(0).isSquare() || !NewMatrix(3).isSquare() {
+		t.Fatal("NewMatrix result reported not square")
+	}
+}
+
+func TestGetMunkresMinScoreNoCopyRestoresInput(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, 7)
+		if trial%4 == 0 {
+			for j := zero64; j < m.N; j++ {
+				m.SetElement(3, j, math.Inf(1))
+			}
+		}
+		orig := append([]float64(nil), m.A...)
+		got := GetMunkresMinScoreNoCopy(m)
+		for idx := range orig {
+			if m.A[idx] != orig[idx] {
+				t.Fatalf("trial %d: element %d changed from %v to %v", trial, idx, orig[idx], m.A[idx])
+			}
+		}
+		if want := GetMunkresMinScore(m); got != want {
+			t.Fatalf("trial %d: GetMunkresMinScoreNoCopy = %v, want %v", trial, got, want)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !munkres_checks
+
+package munkres
+
+const debugChecks = false
@@ -0,0 +1,11 @@
+//go:build !munkres_checks
+
+package munkres
+
+import "testing"
+
+func TestChecksDisabledWithoutTag(t *testing.T) {
+	if debugChecks {
+		t.Fatal("debugChecks enabled without the munkres_checks tag")
+	}
+}
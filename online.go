@@ -0,0 +1,42 @@
+package munkres
+
+//OnlineSolver keeps the optimal assignment of a problem that grows one worker (row) and one task (column) at a time.
+//Each re-solve is warm started from the previous assignment. The zero value is an empty problem ready to use.
+type OnlineSolver struct {
+	m      *FloatMatrix
+	result []Assignment
+	total  float64
+}
+
+//AddWorkerTask grows the problem by a worker and a task and re-solves it. rowCosts holds the new worker's cost for each
+//existing task, colCosts each existing worker's cost for the new task, and corner the new worker's cost for the new
+//task. On error the problem is left as it was.
+func (o *OnlineSolver) AddWorkerTask(rowCosts, colCosts []float64, corner float64) error {
+	if o.m == nil {
+		o.m = NewMatrix(0)
+	}
+	grown, err := o.m.AppendRowCol(rowCosts, colCosts, corner)
+	if err != nil {
+		return err
+	}
+	warm := make([][2]int64, len(o.result))
+	for k, a := range o.result {
+		warm[k] = [2]int64{a.Row, a.Col}
+	}
+	result, total, err := NewSolver(WithWarmStart(warm)).Solve(grown)
+	if err != nil {
+		return err
+	}
+	o.m, o.result, o.total = grown, result, total
+	return nil
+}
+
+//Assignment returns the current optimal assignment
+func (o *OnlineSolver) Assignment() []Assignment {
+	return append([]Assignment(nil), o.result...)
+}
+
+//Total returns the cost of the current optimal assignment
+func (o *OnlineSolver) Total() float64 {
+	return o.total
+}
@@ -0,0 +1,53 @@
+package munkres
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestOnlineSolverMatchesColdSolves(t *testing.T) {
+	r := rand.New(rand.NewSource(25))
+	full := randomMatrix(r, 3)
+	var o OnlineSolver
+	for n := int64(1); n <= full.N; n++ {
+		rowCosts := make([]float64, n-1)
+		colCosts := make([]float64, n-1)
+		for k := int64(0); k < n-1; k++ {
+			rowCosts[k] = full.GetElement(n-1, k)
+			colCosts[k] = full.GetElement(k, n-1)
+		}
+		if err := o.AddWorkerTask(rowCosts, colCosts, full.GetElement(n-1, n-1)); err != nil {
+			t.Fatal(err)
+		}
+		stage := NewMatrix(n)
+		for i := int64(0); i < n; i++ {
+			copy(stage.A[i*n:(i+1)*n], full.A[i*full.N:i*full.N+n])
+		}
+		_, want, err := Solve(stage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAssignment(t, stage, o.Assignment(), o.Total())
+		if o.Total() != want {
+			t.Fatalf("stage %d: online total %v, cold solve %v", n, o.Total(), want)
+		}
+	}
+}
+
+func TestOnlineSolverKeepsStateOnError(t *testing.T) {
+	var o OnlineSolver
+	if err := o.AddWorkerTask(nil, nil, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.AddWorkerTask([]float64{1, 2}, []float64{1}, 0); err == nil {
+		t.Fatal("mismatched cost lengths accepted")
+	}
+	//a new worker that can only take the task the first worker needs makes the problem infeasible
+	if err := o.AddWorkerTask([]float64{1}, []float64{math.Inf(1)}, math.Inf(1)); err == nil {
+		t.Fatal("infeasible growth accepted")
+	}
+	if len(o.Assignment()) != 1 || o.Total() != 3 {
+		t.Fatalf("failed additions changed the problem: %v totalling %v", o.Assignment(), o.Total())
+	}
+}
@@ -0,0 +1,74 @@
+package munkres
+
+import "fmt"
+
+//checkOptimality verifies complementary slackness once a solve has finished: every row and column holds exactly one
+//star, every starred cell has a reduced cost of zero, no cell has a negative reduced cost and the zeros admit a
+//perfect matching.
+//Any violation means the steps corrupted their marks, most likely while converting a path in step5.
+func checkOptimality(ctx *context) error {
+	n := ctx.m.N
+	rowStars := make([]int, n)
+	colStars := make([]int, n)
+	for i := zero64; i < n; i++ {
+		rowStart := i * n
+		for j := zero64; j < n; j++ {
+			pos := rowStart + j
+			v := ctx.m.A[pos]
+			if ctx.less(v, 0) && !ctx.zero(v) {
+				return fmt.Errorf("munkres: reduced cost %v at (%d,%d) is negative", v, i, j)
+			}
+			if ctx.marked[pos] == Starred {
+				if !ctx.zero(v) {
+					return fmt.Errorf("munkres: starred cell (%d,%d) has reduced cost %v", i, j, v)
+				}
+				rowStars[i]++
+				colStars[j]++
+			}
+		}
+	}
+	for k := zero64; k < n; k++ {
+		if rowStars[k] != 1 {
+			return fmt.Errorf("munkres: row %d has %d stars", k, rowStars[k])
+		}
+		if colStars[k] != 1 {
+			return fmt.Errorf("munkres: column %d has %d stars", k, colStars[k])
+		}
+	}
+	if !hasCompleteZeroMatching(ctx) {
+		return fmt.Errorf("munkres: reduced matrix has no perfect matching on its zeros")
+	}
+	return nil
+}
+
+//hasCompleteZeroMatching reports whether the zeros of the current reduced matrix admit a perfect matching, which is
+//exactly the condition under which step3 stops. It searches augmenting paths itself rather than trusting the marks.
+func hasCompleteZeroMatching(ctx *context) bool {
+	n := ctx.m.N
+	rowOf := make([]int64, n)
+	for j := range rowOf {
+		rowOf[j] = -1
+	}
+	var visited []bool
+	var augment func(i int64) bool
+	augment = func(i int64) bool {
+		for j := zero64; j < n; j++ {
+			if !ctx.zero(ctx.m.A[i*n+j]) || visited[j] {
+				continue
+			}
+			visited[j] = true
+			if rowOf[j] < 0 || augment(rowOf[j]) {
+				rowOf[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := zero64; i < n; i++ {
+		visited = make([]bool, n)
+		if !augment(i) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package munkres
+
+import (
+	"math/rand"
+	"testing"
+)
+
+//solvedContext returns the context of a finished solve of m
+func solvedContext(t *testing.T, m *FloatMatrix) *context {
+	t.Helper()
+	ctx := newContext(m)
+	if err := ctx.run(); err != nil {
+		t.Fatal(err)
+	}
+	return ctx
+}
+
+func TestCheckOptimalityAcceptsSolves(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	for trial := 0; trial < 20; trial++ {
+		if err := checkOptimality(solvedContext(t, randomMatrix(r, 6))); err != nil {
+			t.Fatalf("trial %d: %v", trial, err)
+		}
+	}
+}
+
+func TestCheckOptimalityDetectsCorruption(t *testing.T) {
+	m := randomMatrix(rand.New(rand.NewSource(11)), 5)
+	corruptions := map[string]func(ctx *context){
+		"missing star": func(ctx *context) {
+			ctx.marked[ctx.starredPositions()[0]] = Unset
+		},
+		"star on a nonzero cell": func(ctx *context) {
+			for idx, markedVal := range ctx.marked {
+				if markedVal != Starred && ctx.m.A[idx] != 0 {
+					ctx.marked[idx] = Starred
+					return
+				}
+			}
+		},
+		"negative reduced cost": func(ctx *context) {
+			for idx, markedVal := range ctx.marked {
+				if markedVal != Starred {
+					ctx.m.A[idx] = -1
+					return
+				}
+			}
+		},
+	}
+	for name, corrupt := range corruptions {
+		ctx := solvedContext(t, m)
+		corrupt(ctx)
+		if checkOptimality(ctx) == nil {
+			t.Errorf("%s was not detected", name)
+		}
+	}
+}
+
+func TestHasCompleteZeroMatching(t *testing.T) {
+	//the zeros of the first matrix cover a permutation, but rows 1 and 2 of the second only have zeros in column 0
+	matching := &FloatMatrix{N: 3, A: []float64{1, 0, 1, 0, 1, 1, 1, 1, 0}}
+	if !hasCompleteZeroMatching(newContext(matching)) {
+		t.Fatal("zero matching not found")
+	}
+	noMatching := &FloatMatrix{N: 3, A: []float64{0, 0, 1, 0, 1, 1, 0, 1, 1}}
+	if hasCompleteZeroMatching(newContext(noMatching)) {
+		t.Fatal("zero matching reported where there is none")
+	}
+	r := rand.New(rand.NewSource(21))
+	for trial := 0; trial < 20; trial++ {
+		if !hasCompleteZeroMatching(solvedContext(t, randomMatrix(r, 6))) {
+			t.Fatalf("trial %d: solved reduced matrix has no zero matching", trial)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package munkres
+
+import "fmt"
+
+//RectMatrix is a Rows x Cols matrix of costs stored row by row, for problems with more workers than tasks or the
+//reverse
+type RectMatrix struct {
+	Rows int64
+	Cols int64
+	A    []float64
+}
+
+//NewRectMatrix will return a pointer to a new RectMatrix
+func NewRectMatrix(rows, cols int64) *RectMatrix {
+	return &RectMatrix{Rows: rows, Cols: cols, A: make([]float64, rows*cols)}
+}
+
+//GetElement will return the element of the matrix at position (i,j)
+func (m RectMatrix) GetElement(i int64, j int64) float64 {
+	return m.A[i*m.Cols+j]
+}
+
+//SetElement will set the element of the matrix at position (i,j)
+func (m RectMatrix) SetElement(i int64, j int64, v float64) {
+	m.A[i*m.Cols+j] = v
+}
+
+//RectResult is the solution of a rectangular problem
+type RectResult struct {
+	//Pairs holds one matched pair for each row or each column, whichever there are fewer of
+	Pairs []Assignment
+	//Total is the summed cost of Pairs
+	Total float64
+	//UnmatchedRows and UnmatchedCols list, in ascending order, the rows and columns left without a partner
+	UnmatchedRows []int64
+	UnmatchedCols []int64
+}
+
+//SolveRect matches every row or every column of m, whichever there are fewer of, at the lowest total cost.
+//The matrix is padded to a square with zero cost dummy rows or columns, and whatever is matched to a dummy is reported
+//as unmatched.
+func SolveRect(m *RectMatrix) (RectResult, error) {
+	if m.Rows < 0 || m.Cols < 0 || int64(len(m.A)) != m.Rows*m.Cols {
+		return RectResult{}, fmt.Errorf("munkres: %d elements for a %dx%d matrix: %w", len(m.A), m.Rows, m.Cols, ErrDimensionMismatch)
+	}
+	n := m.Rows
+	if m.Cols > n {
+		n = m.Cols
+	}
+	square := NewMatrix(n)
+	for i := zero64; i < m.Rows; i++ {
+		copy(square.A[i*n:], m.A[i*m.Cols:(i+1)*m.Cols])
+	}
+	if err := square.Validate(); err != nil {
+		return RectResult{}, err
+	}
+	perm, err := solvePerm(square)
+	if err != nil {
+		return RectResult{}, err
+	}
+	var res RectResult
+	colMatched := make([]bool, m.Cols)
+	for i, j := range perm {
+		row := int64(i)
+		switch {
+		case row < m.Rows && j < m.Cols:
+			a := Assignment{Row: row, Col: j, Cost: m.GetElement(row, j)}
+			res.Pairs = append(res.Pairs, a)
+			res.Total += a.Cost
+			colMatched[j] = true
+		case row < m.Rows:
+			res.UnmatchedRows = append(res.UnmatchedRows, row)
+		}
+	}
+	for j, matched := range colMatched {
+		if !matched {
+			res.UnmatchedCols = append(res.UnmatchedCols, int64(j))
+		}
+	}
+	return res, nil
+}
+
+//SolveWithCapacities assigns every column of m, a task, to a row, a worker, where row i may take up to rowCap[i] tasks.
+//Each row is expanded into rowCap[i] identical copies and the resulting rectangular problem is solved with SolveRect,
+//so the copies' pairs are reported under the original row, grouped by row in ascending order. rowCap must hold N
+//non-negative capacities summing to at least N. No row can take more than all N columns, so larger capacities are
+//treated as N.
+func SolveWithCapacities(m *FloatMatrix, rowCap []int64) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if int64(len(rowCap)) != m.N {
+		return nil, 0, fmt.Errorf("munkres: %d row capacities for a matrix of size %d: %w", len(rowCap), m.N, ErrDimensionMismatch)
+	}
+	caps := make([]int64, m.N)
+	var total int64
+	for i, c := range rowCap {
+		if c < 0 {
+			return nil, 0, fmt.Errorf("munkres: row %d has negative capacity %d", i, c)
+		}
+		if c > m.N {
+			c = m.N
+		}
+		caps[i] = c
+		total += c
+	}
+	if total < m.N {
+		return nil, 0, fmt.Errorf("munkres: total row capacity %d cannot cover %d columns", total, m.N)
+	}
+	expanded := NewRectMatrix(total, m.N)
+	owner := make([]int64, 0, total)
+	for i, c := range caps {
+		for k := zero64; k < c; k++ {
+			copy(expanded.A[int64(len(owner))*m.N:], m.A[int64(i)*m.N:int64(i+1)*m.N])
+			owner = append(owner, int64(i))
+		}
+	}
+	res, err := SolveRect(expanded)
+	if err != nil {
+		return nil, 0, err
+	}
+	for k := range res.Pairs {
+		res.Pairs[k].Row = owner[res.Pairs[k].Row]
+	}
+	return res.Pairs, res.Total, nil
+}
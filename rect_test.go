@@ -0,0 +1,130 @@
+package munkres
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//bruteForceRect returns the lowest total of the matchings of m that pair every row or every column, whichever there
+//are fewer of
+func bruteForceRect(m *RectMatrix) float64 {
+	rows, cols := m.Rows, m.Cols
+	get := m.GetElement
+	if rows > cols {
+		rows, cols = cols, rows
+		get = func(i, j int64) float64 { return m.GetElement(j, i) }
+	}
+	used := make([]bool, cols)
+	best := math.Inf(1)
+	var pick func(i int64, total float64)
+	pick = func(i int64, total float64) {
+		if i == rows {
+			best = math.Min(best, total)
+			return
+		}
+		for j := int64(0); j < cols; j++ {
+			if !used[j] {
+				used[j] = true
+				pick(i+1, total+get(i, j))
+				used[j] = false
+			}
+		}
+	}
+	pick(0, 0)
+	return best
+}
+
+func TestSolveRectUnmatchedSets(t *testing.T) {
+	//more rows than columns: row 1 is the worst for both columns
+	tall := &RectMatrix{Rows: 3, Cols: 2, A: []float64{
+		1, 5,
+		9, 9,
+		5, 1,
+	}}
+	res, err := SolveRect(tall)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 2 || len(res.Pairs) != 2 || fmt.Sprint(res.UnmatchedRows) != "[1]" || res.UnmatchedCols != nil {
+		t.Fatalf("tall matrix: %+v", res)
+	}
+	//more columns than rows: column 1 is the worst for both rows
+	wide := &RectMatrix{Rows: 2, Cols: 3, A: []float64{
+		1, 9, 5,
+		5, 9, 1,
+	}}
+	if res, err = SolveRect(wide); err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 2 || len(res.Pairs) != 2 || res.UnmatchedRows != nil || fmt.Sprint(res.UnmatchedCols) != "[1]" {
+		t.Fatalf("wide matrix: %+v", res)
+	}
+	if _, err := SolveRect(&RectMatrix{Rows: 2, Cols: 3, A: make([]float64, 5)}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("short matrix: err = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestSolveRectMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(32))
+	for trial := 0; trial < 50; trial++ {
+		m := NewRectMatrix(int64(1+r.Intn(5)), int64(1+r.Intn(5)))
+		for idx := range m.A {
+			m.A[idx] = float64(r.Intn(50))
+		}
+		res, err := SolveRect(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := bruteForceRect(m); res.Total != want {
+			t.Fatalf("trial %d: %dx%d total %v, want %v", trial, m.Rows, m.Cols, res.Total, want)
+		}
+		rows, cols := map[int64]bool{}, map[int64]bool{}
+		for _, a := range res.Pairs {
+			if rows[a.Row] || cols[a.Col] || a.Cost != m.GetElement(a.Row, a.Col) {
+				t.Fatalf("trial %d: bad pair %+v in %+v", trial, a, res)
+			}
+			rows[a.Row], cols[a.Col] = true, true
+		}
+		for _, i := range res.UnmatchedRows {
+			rows[i] = true
+		}
+		for _, j := range res.UnmatchedCols {
+			cols[j] = true
+		}
+		if int64(len(rows)) != m.Rows || int64(len(cols)) != m.Cols {
+			t.Fatalf("trial %d: pairs and unmatched sets do not account for every row and column: %+v", trial, res)
+		}
+	}
+}
+
+func TestSolveWithCapacitiesSharesARow(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{
+		1, 1, 9,
+		5, 5, 5,
+		9, 9, 1,
+	}}
+	result, total, err := SolveWithCapacities(m, []int64{2, 1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(result) != 3 || result[0].Row != 0 || result[1].Row != 0 || result[2].Row != 2 {
+		t.Fatalf("result %v, total %v; want row 0 taking columns 0 and 1 and row 2 column 2", result, total)
+	}
+	if _, _, err := SolveWithCapacities(m, []int64{1, 1, 0}); err == nil {
+		t.Fatal("capacity below N was accepted")
+	}
+}
+
+func TestSolveWithCapacitiesClampsHugeCapacities(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 1, 9, 9}}
+	result, total, err := SolveWithCapacities(m, []int64{1 << 40, math.MaxInt64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || result[0].Row != 0 || result[1].Row != 0 {
+		t.Fatalf("result %v, total %v; want row 0 taking both columns", result, total)
+	}
+}
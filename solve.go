@@ -0,0 +1,562 @@
+package munkres
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+//Assignment is a single row to column pairing chosen by the solver along with its cost
+type Assignment struct {
+	Row  int64
+	Col  int64
+	Cost float64
+}
+
+//Solve validates m and returns the lowest cost assignment along with its total cost.
+//Cells set to +Inf are forbidden; ErrInfeasible is returned if no assignment avoids them.
+func Solve(m *FloatMatrix) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	ctx := newContext(m)
+	if err := ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	return assignments(m, ctx.assignment()), ctx.score(m), nil
+}
+
+//assignments pairs every row with its column in perm, taking the cost from m
+func assignments(m *FloatMatrix, perm []int64) []Assignment {
+	result := make([]Assignment, len(perm))
+	for i, j := range perm {
+		result[i] = Assignment{Row: int64(i), Col: j, Cost: m.GetElement(int64(i), j)}
+	}
+	return result
+}
+
+//SolveWithDeadline behaves like Solve but gives up once d has elapsed.
+//If the deadline passes first, the stars found so far are completed greedily with the cheapest free columns
+//and the returned flag reports false since the assignment is not proven optimal. Should the greedy completion be
+//unable to avoid the forbidden cells, the solve is finished after all and its optimal result returned.
+func SolveWithDeadline(m *FloatMatrix, d time.Duration) ([]Assignment, float64, bool, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, false, err
+	}
+	deadline := time.Now().Add(d)
+	ctx := newContext(m)
+	ctx.stop = func(step) bool {
+		return !time.Now().Before(deadline)
+	}
+	return bestEffort(m, ctx)
+}
+
+//SolveApprox behaves like Solve but runs step 6 at most maxStep6 times, bounding the work done.
+//If the limit is reached, the stars found so far are completed greedily with the cheapest free columns
+//and the returned flag reports false; the total is then never lower than the optimum. Should the greedy completion be
+//unable to avoid the forbidden cells, the solve is finished after all and its optimal result returned.
+func SolveApprox(m *FloatMatrix, maxStep6 int) ([]Assignment, float64, bool, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, false, err
+	}
+	ctx := newContext(m)
+	ctx.stop = func(next step) bool {
+		_, isStep6 := next.(step6)
+		return isStep6 && ctx.stats.StepCounts[5] >= maxStep6
+	}
+	return bestEffort(m, ctx)
+}
+
+//bestEffort runs ctx and, if its stop function ended the run early, completes the partial starring greedily,
+//resuming the run without its stop function when the greedy pass would need a forbidden cell
+func bestEffort(m *FloatMatrix, ctx *context) ([]Assignment, float64, bool, error) {
+	err := ctx.run()
+	if err == errStopped {
+		if perm, ok := completeGreedy(m, ctx.assignment()); ok {
+			result := assignments(m, perm)
+			var total float64
+			for _, a := range result {
+				total += a.Cost
+			}
+			return result, total, false, nil
+		}
+		ctx.stop = nil
+		err = ctx.run()
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return assignments(m, ctx.assignment()), ctx.score(m), true, nil
+}
+
+//completeGreedy fills the rows of perm that are -1 with the cheapest allowed column no other row uses, reporting false
+//if some row is left with only forbidden (+Inf) columns
+func completeGreedy(m *FloatMatrix, perm []int64) ([]int64, bool) {
+	used := make([]bool, m.N)
+	for _, j := range perm {
+		if j >= 0 {
+			used[j] = true
+		}
+	}
+	for i, j := range perm {
+		if j >= 0 {
+			continue
+		}
+		best := int64(-1)
+		for k := zero64; k < m.N; k++ {
+			v := m.GetElement(int64(i), k)
+			if !used[k] && !math.IsInf(v, 1) && (best < 0 || v < m.GetElement(int64(i), best)) {
+				best = k
+			}
+		}
+		if best < 0 {
+			return nil, false
+		}
+		used[best] = true
+		perm[i] = best
+	}
+	return perm, true
+}
+
+//GetMunkresAssignmentsSorted returns the lowest cost assignment ordered from cheapest to most expensive pair,
+//breaking ties by row. It returns nil if no assignment avoids the forbidden cells.
+func GetMunkresAssignmentsSorted(m *FloatMatrix) []Assignment {
+	ctx := newContext(m)
+	if ctx.run() != nil {
+		return nil
+	}
+	result := assignments(m, ctx.assignment())
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Cost != result[b].Cost {
+			return result[a].Cost < result[b].Cost
+		}
+		return result[a].Row < result[b].Row
+	})
+	return result
+}
+
+//GetMunkresMinScoreStable returns the same lowest cost as GetMunkresMinScore but adds up the chosen cells in row order
+//using compensated (Kahan-Babuska) summation. The rounding error of the total is bounded by about twice the unit
+//roundoff of the result regardless of N or of how widely the cell magnitudes vary.
+func GetMunkresMinScoreStable(m *FloatMatrix) float64 {
+	ctx := newContext(m)
+	if ctx.run() != nil {
+		return math.Inf(1)
+	}
+	var sum, compensation float64
+	for i, j := range ctx.assignment() {
+		v := m.GetElement(int64(i), j)
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			compensation += (sum - t) + v
+		} else {
+			compensation += (v - t) + sum
+		}
+		sum = t
+	}
+	return sum + compensation
+}
+
+//SolveTransportationUnit solves m as a transportation problem in which every row supplies exactly one unit and every
+//column demands exactly one unit, the special case that reduces to assignment. Balanced unit supply and demand
+//requires a square matrix, so anything else is rejected with ErrNotSquare before delegating to Solve.
+func SolveTransportationUnit(m *FloatMatrix) ([]Assignment, float64, error) {
+	if !m.isSquare() {
+		return nil, 0, fmt.Errorf("munkres: unit supply and demand need as many rows as columns: %w", ErrNotSquare)
+	}
+	return Solve(m)
+}
+
+//GetMunkresMaxScore returns the sum of the elements that comprise the highest value path.
+//Cells set to +Inf are still forbidden; if no path avoids them the result is -Inf.
+func GetMunkresMaxScore(m *FloatMatrix) float64 {
+	profits := NewMatrix(m.N)
+	copy(profits.A, m.A)
+	profits.ToMaximization()
+	ctx := newContext(profits)
+	if ctx.run() != nil {
+		return math.Inf(-1)
+	}
+	return ctx.score(m)
+}
+
+//GetMunkresMaxAssignments returns the highest value assignment and its total, as GetMunkresMaxScore does. The solve
+//runs on a converted copy of m, so every reported cost and the total are the original profits. Cells set to +Inf are
+//still forbidden; if no assignment avoids them the result is nil and -Inf.
+func GetMunkresMaxAssignments(m *FloatMatrix) ([]Assignment, float64) {
+	profits := NewMatrix(m.N)
+	copy(profits.A, m.A)
+	profits.ToMaximization()
+	ctx := newContext(profits)
+	if ctx.run() != nil {
+		return nil, math.Inf(-1)
+	}
+	return assignments(m, ctx.assignment()), ctx.score(m)
+}
+
+//GetMunkresLabeled solves m and returns the lowest cost assignment as (rowLabels[i], colLabels[j]) pairs in row order,
+//sparing callers the index bookkeeping. Both label slices must hold N labels.
+func GetMunkresLabeled(m *FloatMatrix, rowLabels, colLabels []string) ([][2]string, error) {
+	if int64(len(rowLabels)) != m.N || int64(len(colLabels)) != m.N {
+		return nil, fmt.Errorf("munkres: %d row and %d column labels for a matrix of size %d: %w",
+			len(rowLabels), len(colLabels), m.N, ErrDimensionMismatch)
+	}
+	result, _, err := Solve(m)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([][2]string, len(result))
+	for k, a := range result {
+		pairs[k] = [2]string{rowLabels[a.Row], colLabels[a.Col]}
+	}
+	return pairs, nil
+}
+
+//SolveLogProb treats every element of m as a log-probability and returns the assignment maximizing their sum, which
+//maximizes the product of the probabilities, along with that summed log-probability.
+//Cells of -Inf have probability zero and are never chosen; ErrInfeasible is returned if they cannot be avoided.
+func SolveLogProb(m *FloatMatrix) ([]Assignment, float64, error) {
+	if !m.isSquare() {
+		return nil, 0, ErrNotSquare
+	}
+	costs := NewMatrix(m.N)
+	for idx, v := range m.A {
+		costs.A[idx] = -v
+	}
+	if err := costs.Validate(); err != nil {
+		return nil, 0, err
+	}
+	ctx := newContext(costs)
+	if err := ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	return assignments(m, ctx.assignment()), ctx.score(m), nil
+}
+
+//SolveUnderBudget returns the lowest cost assignment and whether its total fits within budget.
+//Because that total is the minimum possible, a false result means no assignment fits the budget.
+//Invalid or infeasible matrices return nil and false.
+func SolveUnderBudget(m *FloatMatrix, budget float64) ([]Assignment, bool) {
+	result, total, err := Solve(m)
+	if err != nil {
+		return nil, false
+	}
+	return result, total <= budget
+}
+
+//GetMunkresStarredPositions returns the flat positions row*N+col in A of the lowest cost assignment's cells,
+//in ascending order and therefore one per row. It returns nil if no assignment avoids the forbidden cells.
+func GetMunkresStarredPositions(m *FloatMatrix) []int {
+	ctx := newContext(m)
+	if ctx.run() != nil {
+		return nil
+	}
+	return ctx.starredPositions()
+}
+
+//SolveScoreAndPerm returns the lowest cost together with perm, where perm[i] is the column assigned to row i,
+//collecting both in a single pass over the marks. It returns +Inf and nil if no assignment avoids the forbidden cells.
+func SolveScoreAndPerm(m *FloatMatrix) (float64, []int64) {
+	ctx := newContext(m)
+	if ctx.run() != nil {
+		return math.Inf(1), nil
+	}
+	n := m.N
+	perm := make([]int64, n)
+	var total float64
+	for pos, markedVal := range ctx.marked {
+		if markedVal == Starred {
+			perm[int64(pos)/n] = int64(pos) % n
+			total += m.A[pos]
+		}
+	}
+	return total, perm
+}
+
+//SolveAllowNegative accepts negative costs explicitly: when m has any, every cell is shifted up by the magnitude of the
+//most negative one before solving. Shifting all cells by the same amount adds N times that amount to every complete
+//assignment, so the chosen assignment is unchanged; the reported costs and total come from the unshifted m.
+func SolveAllowNegative(m *FloatMatrix) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	lowest := 0.0
+	for _, v := range m.A {
+		lowest = math.Min(lowest, v)
+	}
+	shifted := NewMatrix(m.N)
+	for idx, v := range m.A {
+		shifted.A[idx] = v - lowest
+	}
+	ctx := newContext(shifted)
+	if err := ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	return assignments(m, ctx.assignment()), ctx.score(m), nil
+}
+
+//GetMunkresInversePermutation returns the lowest cost assignment indexed by column, so inv[col] is the row assigned to
+//col. It returns nil if no assignment avoids the forbidden cells.
+func GetMunkresInversePermutation(m *FloatMatrix) []int64 {
+	ctx := newContext(m)
+	if ctx.run() != nil {
+		return nil
+	}
+	inv := make([]int64, m.N)
+	for i, j := range ctx.assignment() {
+		inv[j] = int64(i)
+	}
+	return inv
+}
+
+//SolveWithSymmetryWarning solves m like Solve and also reports every pair (i,j), with i < j, whose mirrored elements
+//differ by more than tol. It is meant for cost models that should be symmetric, where such pairs usually mean bad data.
+func SolveWithSymmetryWarning(m *FloatMatrix, tol float64) ([]Assignment, float64, [][2]int64, error) {
+	result, total, err := Solve(m)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	var asymmetric [][2]int64
+	for i := zero64; i < m.N; i++ {
+		for j := i + 1; j < m.N; j++ {
+			a, b := m.GetElement(i, j), m.GetElement(j, i)
+			if a != b && !(math.Abs(a-b) <= tol) {
+				asymmetric = append(asymmetric, [2]int64{i, j})
+			}
+		}
+	}
+	return result, total, asymmetric, nil
+}
+
+//SolveWithInterchangeability solves m like Solve and also returns groups of rows with identical cost vectors.
+//Rows in a group can swap their assigned columns without changing the total, so which of them got which column is
+//arbitrary; callers allocating fairly can redistribute within each group.
+func SolveWithInterchangeability(m *FloatMatrix) ([]Assignment, float64, [][]int64, error) {
+	result, total, err := Solve(m)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return result, total, DuplicateRows(m, 0), nil
+}
+
+//SolveWithSink solves m and calls sink once per chosen pair, in row order, as the pairs are read off the solution,
+//instead of building a slice. It returns the total cost.
+func SolveWithSink(m *FloatMatrix, sink func(row, col int64, cost float64)) (float64, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+	ctx := newContext(m)
+	if err := ctx.run(); err != nil {
+		return 0, err
+	}
+	n := m.N
+	var total float64
+	for pos, markedVal := range ctx.marked {
+		if markedVal == Starred {
+			total += m.A[pos]
+			sink(int64(pos)/n, int64(pos)%n, m.A[pos])
+		}
+	}
+	return total, nil
+}
+
+//SolveFixedPoint multiplies every cost by scale, rounds it to an integer and solves the integer problem, reporting
+//costs and the total divided back by scale. Every value the solver computes is then an integer that float64 holds
+//exactly, so the result is identical on every platform; the price is a rounding error of at most 0.5/scale per cell.
+//Forbidden (+Inf) cells stay forbidden. An error is returned if N times the largest scaled cost reaches 2^53.
+func SolveFixedPoint(m *FloatMatrix, scale int64) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if scale <= 0 {
+		return nil, 0, fmt.Errorf("munkres: fixed point scale %d is not positive", scale)
+	}
+	fixed := NewMatrix(m.N)
+	var largest float64
+	for idx, v := range m.A {
+		if !math.IsInf(v, 1) {
+			v = math.Round(v * float64(scale))
+			largest = math.Max(largest, math.Abs(v))
+		}
+		fixed.A[idx] = v
+	}
+	if largest*float64(m.N) >= 1<<53 {
+		return nil, 0, fmt.Errorf("munkres: costs scaled by %d exceed the exact integer range of float64", scale)
+	}
+	ctx := newContext(fixed)
+	if err := ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	result := assignments(fixed, ctx.assignment())
+	var total int64
+	for k := range result {
+		units := int64(result[k].Cost)
+		total += units
+		result[k].Cost = float64(units) / float64(scale)
+	}
+	return result, float64(total) / float64(scale), nil
+}
+
+//AugmentingPath is the sequence of cells step 5 flips to grow the matching by one. It starts at an uncovered primed
+//zero and alternates starred and primed zeros, each in the column or row of the one before, ending at a primed zero.
+//The primes along it become stars and the stars lose their mark.
+type AugmentingPath [][2]int64
+
+//SolveWithPaths returns every augmenting path found while solving m, in the order step 5 applied them, together with
+//the lowest cost. Stars placed by step 2 need no path, so there are at most N paths. It returns nil and +Inf if no
+//assignment avoids the forbidden cells.
+func SolveWithPaths(m *FloatMatrix) ([]AugmentingPath, float64) {
+	ctx := newContext(m)
+	var paths []AugmentingPath
+	ctx.onPath = func(rows, cols []int64) {
+		path := make(AugmentingPath, len(rows))
+		for k := range rows {
+			path[k] = [2]int64{rows[k], cols[k]}
+		}
+		paths = append(paths, path)
+	}
+	if ctx.run() != nil {
+		return nil, math.Inf(1)
+	}
+	return paths, ctx.score(m)
+}
+
+//SolveWithPreferences behaves like Solve but lets step 2 break ties by preference: among the zeros of row i after
+//reduction, the columns listed in prefs[i] are starred first, most preferred first, before the rest in column order.
+//This only steers the initial matching, so the total is always optimal and a preference may still be overridden
+//later when it conflicts with optimality. prefs must hold one list per row; a list may be empty or partial.
+func SolveWithPreferences(m *FloatMatrix, prefs [][]int64) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if int64(len(prefs)) != m.N {
+		return nil, 0, fmt.Errorf("munkres: %d preference lists for a matrix of size %d: %w", len(prefs), m.N, ErrDimensionMismatch)
+	}
+	for i, list := range prefs {
+		for _, j := range list {
+			if j < 0 || j >= m.N {
+				return nil, 0, fmt.Errorf("munkres: row %d prefers column %d of a matrix of size %d: %w", i, j, m.N, ErrOutOfRange)
+			}
+		}
+	}
+	ctx := newContext(m)
+	ctx.prefs = prefs
+	if err := ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	return assignments(m, ctx.assignment()), ctx.score(m), nil
+}
+
+//SolveVariants solves base once and then each variant obtained by overwriting the cells of base named in a patch,
+//returning the lowest total of every variant in order. Each variant is warm-started from the base assignment, so
+//variants that touch only a few cells need few augmentations. A variant whose patch names a cell outside the matrix,
+//holds a value Validate rejects, or leaves no assignment avoiding the forbidden cells has a total of +Inf. Every total
+//is +Inf when base is not square; a base that is square but fails Validate only loses the warm start.
+func SolveVariants(base *FloatMatrix, patches []map[[2]int64]float64) []float64 {
+	totals := make([]float64, len(patches))
+	for k := range totals {
+		totals[k] = math.Inf(1)
+	}
+	if !base.isSquare() {
+		return totals
+	}
+	var warm [][2]int64
+	if base.Validate() == nil {
+		if ctx := newContext(base); ctx.run() == nil {
+			for i, j := range ctx.assignment() {
+				warm = append(warm, [2]int64{int64(i), j})
+			}
+		}
+	}
+	for k, patch := range patches {
+		variant := NewMatrix(base.N)
+		copy(variant.A, base.A)
+		valid := true
+		for cell, v := range patch {
+			i, j := cell[0], cell[1]
+			if i < 0 || j < 0 || i >= base.N || j >= base.N {
+				valid = false
+				break
+			}
+			variant.SetElement(i, j, v)
+		}
+		if !valid || variant.Validate() != nil {
+			continue
+		}
+		ctx := newContext(variant)
+		ctx.warm = warm
+		if ctx.run() == nil {
+			totals[k] = ctx.score(variant)
+		}
+	}
+	return totals
+}
+
+//SolveTreatNaNAsForbidden behaves like Solve but reads NaN cells, which some data pipelines use for missing data, as
+//forbidden instead of rejecting them. ErrInfeasible is returned if no assignment avoids both the NaN and +Inf cells.
+func SolveTreatNaNAsForbidden(m *FloatMatrix) ([]Assignment, float64, error) {
+	if !m.isSquare() {
+		return nil, 0, ErrNotSquare
+	}
+	costs := NewMatrix(m.N)
+	for idx, v := range m.A {
+		if math.IsNaN(v) {
+			v = math.Inf(1)
+		}
+		costs.A[idx] = v
+	}
+	if err := costs.Validate(); err != nil {
+		return nil, 0, err
+	}
+	ctx := newContext(costs)
+	if err := ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	return assignments(m, ctx.assignment()), ctx.score(m), nil
+}
+
+//SolveWithCapture returns the lowest cost of m, as GetMunkresMinScore does, along with a function that renders m and
+//that cost as a ready-to-paste Go test, for attaching a problematic input to a bug report. The matrix is copied before
+//solving, so the fixture shows the input as it was even if m changes later. A matrix Validate rejects is not solved;
+//its cost is NaN and the fixture asserts that validation fails.
+func SolveWithCapture(m *FloatMatrix) (float64, func() string) {
+	input := &FloatMatrix{N: m.N, A: append([]float64(nil), m.A...)}
+	valid := m.Validate() == nil
+	score := math.NaN()
+	if valid {
+		score = GetMunkresMinScore(m)
+	}
+	return score, func() string {
+		var b strings.Builder
+		b.WriteString("func TestCapturedSolve(t *testing.T) {\n")
+		fmt.Fprintf(&b, "\tm := &munkres.FloatMatrix{N: %d, A: []float64{\n", input.N)
+		width := len(input.A)
+		if input.isSquare() && input.N > 0 {
+			width = int(input.N)
+		}
+		for idx, v := range input.A {
+			if idx%width == 0 {
+				b.WriteString("\t\t")
+			} else {
+				b.WriteString(" ")
+			}
+			b.WriteString(goFloat(v) + ",")
+			if (idx+1)%width == 0 {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("\t}}\n")
+		if valid {
+			want := goFloat(score)
+			fmt.Fprintf(&b, "\tif got := munkres.GetMunkresMinScore(m); got != %s {\n", want)
+			fmt.Fprintf(&b, "\t\tt.Errorf(\"GetMunkresMinScore = %%v, want %%v\", got, %s)\n", want)
+		} else {
+			b.WriteString("\tif err := m.Validate(); err == nil {\n")
+			b.WriteString("\t\tt.Error(\"Validate accepted the captured matrix\")\n")
+		}
+		b.WriteString("\t}\n}\n")
+		return b.String()
+	}
+}
@@ -0,0 +1,732 @@
+package munkres
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+//checkAssignment fails t unless result is a complete permutation of m's columns that avoids forbidden cells and whose
+//costs sum to total
+func checkAssignment(t *testing.T, m *FloatMatrix, result []Assignment, total float64) {
+	t.Helper()
+	perm := make([]int64, len(result))
+	var sum float64
+	for i, a := range result {
+		if a.Row != int64(i) {
+			t.Fatalf("pair %d is for row %d", i, a.Row)
+		}
+		if a.Cost != m.GetElement(a.Row, a.Col) || math.IsInf(a.Cost, 1) {
+			t.Fatalf("pair (%d,%d) has cost %v, matrix holds %v", a.Row, a.Col, a.Cost, m.GetElement(a.Row, a.Col))
+		}
+		perm[i] = a.Col
+		sum += a.Cost
+	}
+	if !IsValidPermutation(perm, m.N) {
+		t.Fatalf("assignment %v is not a permutation", perm)
+	}
+	if sum != total {
+		t.Fatalf("pairs sum to %v, total is %v", sum, total)
+	}
+}
+
+func TestSolveMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 50; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(6)))
+		if trial%2 == 0 && m.N > 1 {
+			m.A[r.Intn(len(m.A))] = math.Inf(1)
+		}
+		result, total, err := Solve(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAssignment(t, m, result, total)
+		if want := bruteForceMin(m); total != want {
+			t.Fatalf("trial %d: total %v, want %v", trial, total, want)
+		}
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	inf := math.Inf(1)
+	if _, _, err := Solve(&FloatMatrix{N: 2, A: []float64{1, inf, inf, inf}}); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("forbidden row: err = %v, want ErrInfeasible", err)
+	}
+	if _, _, err := Solve(&FloatMatrix{N: 2, A: []float64{1, 2, 3}}); !errors.Is(err, ErrNotSquare) {
+		t.Fatalf("short matrix: err = %v, want ErrNotSquare", err)
+	}
+	if _, _, err := Solve(&FloatMatrix{N: 1, A: []float64{math.NaN()}}); err == nil {
+		t.Fatal("NaN cell accepted")
+	}
+}
+
+func TestSolveWithDeadlineExpired(t *testing.T) {
+	m := randomMatrix(rand.New(rand.NewSource(2)), 30)
+	result, total, optimal, err := SolveWithDeadline(m, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if optimal {
+		t.Fatal("expired deadline reported an optimal result")
+	}
+	checkAssignment(t, m, result, total)
+	if want := GetMunkresMinScore(m); total < want {
+		t.Fatalf("best effort total %v is below the optimum %v", total, want)
+	}
+}
+
+func TestSolveWithDeadlineInTime(t *testing.T) {
+	m := randomMatrix(rand.New(rand.NewSource(5)), 30)
+	result, total, optimal, err := SolveWithDeadline(m, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !optimal {
+		t.Fatal("solve finished in time but was not reported optimal")
+	}
+	checkAssignment(t, m, result, total)
+	if want := GetMunkresMinScore(m); total != want {
+		t.Fatalf("total %v, want the optimum %v", total, want)
+	}
+}
+
+func TestSolveWithDeadlineAvoidsForbiddenCells(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 3, math.Inf(1)}}
+	result, total, _, err := SolveWithDeadline(m, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if total != 5 {
+		t.Fatalf("total = %v, want 5", total)
+	}
+}
+
+func TestSolveApproxNeverBeatsExact(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, 12)
+		exact := GetMunkresMinScore(m)
+		for _, limit := range []int{0, 1, 3} {
+			result, total, optimal, err := SolveApprox(m, limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkAssignment(t, m, result, total)
+			if total < exact || (optimal && total != exact) {
+				t.Fatalf("limit %d: total %v (optimal %v), exact %v", limit, total, optimal, exact)
+			}
+		}
+	}
+}
+
+func TestSolveApproxAvoidsForbiddenCells(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 3, math.Inf(1)}}
+	result, total, _, err := SolveApprox(m, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if total != 5 {
+		t.Fatalf("total = %v, want 5", total)
+	}
+}
+
+func TestGetMunkresAssignmentsSorted(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(8)))
+		result := GetMunkresAssignmentsSorted(m)
+		perm := make([]int64, m.N)
+		var total float64
+		for k, a := range result {
+			perm[a.Row] = a.Col
+			total += a.Cost
+			if k > 0 {
+				prev := result[k-1]
+				if a.Cost < prev.Cost || (a.Cost == prev.Cost && a.Row < prev.Row) {
+					t.Fatalf("pairs %+v and %+v are out of order", prev, a)
+				}
+			}
+		}
+		if !IsValidPermutation(perm, m.N) {
+			t.Fatalf("assignment %v is not a permutation", perm)
+		}
+		if want := GetMunkresMinScore(m); total != want {
+			t.Fatalf("sorted pairs total %v, want %v", total, want)
+		}
+	}
+	inf := math.Inf(1)
+	if result := GetMunkresAssignmentsSorted(&FloatMatrix{N: 1, A: []float64{inf}}); result != nil {
+		t.Fatalf("infeasible matrix gave %v", result)
+	}
+}
+
+func TestGetMunkresMinScoreStable(t *testing.T) {
+	//the optimal assignment is the diagonal, whose cells sum to exactly 1; adding them naively in row order loses the 1
+	//against 1e16
+	m := &FloatMatrix{N: 3, A: []float64{
+		1e16, 1e17, 1e17,
+		1e17, 1, 1e17,
+		1e17, 1e17, -1e16,
+	}}
+	var naive float64
+	for i := int64(0); i < m.N; i++ {
+		naive += m.GetElement(i, i)
+	}
+	if naive == 1 {
+		t.Fatal("the naive sum is exact, so the matrix does not exercise compensation")
+	}
+	if got := GetMunkresMinScoreStable(m); got != 1 {
+		t.Fatalf("GetMunkresMinScoreStable = %v, want 1", got)
+	}
+	if got := GetMunkresMinScoreStable(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}); !math.IsInf(got, 1) {
+		t.Fatalf("infeasible matrix gave %v, want +Inf", got)
+	}
+}
+
+func TestSolveTransportationUnit(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	for trial := 0; trial < 20; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(8)))
+		result, total, err := SolveTransportationUnit(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAssignment(t, m, result, total)
+		if want := GetMunkresMinScore(m); total != want {
+			t.Fatalf("total %v, want %v", total, want)
+		}
+	}
+	if _, _, err := SolveTransportationUnit(&FloatMatrix{N: 2, A: make([]float64, 6)}); !errors.Is(err, ErrNotSquare) {
+		t.Fatalf("unbalanced supply and demand: err = %v, want ErrNotSquare", err)
+	}
+}
+
+func TestSolveLogProb(t *testing.T) {
+	probs := []float64{
+		0.9, 0.1, 0,
+		0.8, 0.2, 0.5,
+		0.3, 0.6, 0.4,
+	}
+	m := NewMatrix(3)
+	for idx, p := range probs {
+		m.A[idx] = math.Log(p)
+	}
+	//(0,0),(1,2),(2,1) has probability 0.27, the best of the assignments avoiding the impossible cell (0,2)
+	result, total, err := SolveLogProb(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result[0].Col != 0 || result[1].Col != 2 || result[2].Col != 1 {
+		t.Fatalf("chose %v", result)
+	}
+	if want := math.Log(0.9) + math.Log(0.5) + math.Log(0.6); total != want {
+		t.Fatalf("total %v, want %v", total, want)
+	}
+	impossible := &FloatMatrix{N: 2, A: []float64{0, math.Inf(-1), 0, math.Inf(-1)}}
+	if _, _, err := SolveLogProb(impossible); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("column of zero probability: err = %v, want ErrInfeasible", err)
+	}
+}
+
+func TestSolveUnderBudget(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	for _, c := range []struct {
+		budget float64
+		fits   bool
+	}{{6, true}, {5, true}, {4.5, false}} {
+		result, fits := SolveUnderBudget(m, c.budget)
+		if fits != c.fits {
+			t.Errorf("budget %v: fits = %v, want %v", c.budget, fits, c.fits)
+		}
+		checkAssignment(t, m, result, 5)
+	}
+	if result, fits := SolveUnderBudget(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}, 100); result != nil || fits {
+		t.Fatalf("infeasible matrix gave %v, %v", result, fits)
+	}
+}
+
+func TestGetMunkresStarredPositions(t *testing.T) {
+	r := rand.New(rand.NewSource(18))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(8)))
+		positions := GetMunkresStarredPositions(m)
+		if int64(len(positions)) != m.N {
+			t.Fatalf("trial %d: %d positions for n=%d", trial, len(positions), m.N)
+		}
+		perm := make([]int64, m.N)
+		var total float64
+		for k, pos := range positions {
+			row, col := int64(pos)/m.N, int64(pos)%m.N
+			if row != int64(k) {
+				t.Fatalf("trial %d: position %d is in row %d, want row %d", trial, pos, row, k)
+			}
+			perm[row] = col
+			total += m.A[pos]
+		}
+		if !IsValidPermutation(perm, m.N) {
+			t.Fatalf("trial %d: positions %v do not form a permutation", trial, positions)
+		}
+		if want := GetMunkresMinScore(m); total != want {
+			t.Fatalf("trial %d: positions total %v, want %v", trial, total, want)
+		}
+	}
+	if positions := GetMunkresStarredPositions(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}); positions != nil {
+		t.Fatalf("infeasible matrix gave %v", positions)
+	}
+}
+
+func TestSolveScoreAndPerm(t *testing.T) {
+	r := rand.New(rand.NewSource(19))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(8)))
+		total, perm := SolveScoreAndPerm(m)
+		cost, err := m.PermutationCost(perm)
+		if err != nil {
+			t.Fatalf("trial %d: %v", trial, err)
+		}
+		if cost != total || total != GetMunkresMinScore(m) {
+			t.Fatalf("trial %d: score %v, permutation cost %v, optimum %v", trial, total, cost, GetMunkresMinScore(m))
+		}
+	}
+	if total, perm := SolveScoreAndPerm(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}); !math.IsInf(total, 1) || perm != nil {
+		t.Fatalf("infeasible matrix gave %v, %v", total, perm)
+	}
+}
+
+func TestSolveAllowNegative(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(7)))
+		for idx := range m.A {
+			m.A[idx] -= 25
+		}
+		result, total, err := SolveAllowNegative(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAssignment(t, m, result, total)
+		if _, want, _ := Solve(m); total != want {
+			t.Fatalf("trial %d: total %v, the unshifted solver finds %v", trial, total, want)
+		}
+	}
+	m := &FloatMatrix{N: 2, A: []float64{-5, 1, math.Inf(1), -2}}
+	if _, total, err := SolveAllowNegative(m); err != nil || total != -7 {
+		t.Fatalf("total %v, %v, want -7", total, err)
+	}
+}
+
+func TestGetMunkresInversePermutation(t *testing.T) {
+	r := rand.New(rand.NewSource(24))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(8)))
+		inv := GetMunkresInversePermutation(m)
+		_, perm := SolveScoreAndPerm(m)
+		if !IsValidPermutation(inv, m.N) {
+			t.Fatalf("trial %d: %v is not a permutation", trial, inv)
+		}
+		for i, j := range perm {
+			if inv[j] != int64(i) {
+				t.Fatalf("trial %d: inv[%d] = %d, want %d", trial, j, inv[j], i)
+			}
+		}
+	}
+	if inv := GetMunkresInversePermutation(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}); inv != nil {
+		t.Fatalf("infeasible matrix gave %v", inv)
+	}
+}
+
+func TestSolveWithSymmetryWarning(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{
+		0, 1, 2,
+		1, 0, 3.5,
+		2.05, 3, 0,
+	}}
+	result, total, asymmetric, err := SolveWithSymmetryWarning(m, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if fmt.Sprint(asymmetric) != "[[1 2]]" {
+		t.Fatalf("asymmetric pairs %v, want [[1 2]]", asymmetric)
+	}
+	if _, _, asymmetric, _ = SolveWithSymmetryWarning(m, 0); fmt.Sprint(asymmetric) != "[[0 2] [1 2]]" {
+		t.Fatalf("with no tolerance: asymmetric pairs %v, want [[0 2] [1 2]]", asymmetric)
+	}
+}
+
+func TestSolveWithInterchangeability(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{
+		1, 2, 3,
+		4, 1, 6,
+		1, 2, 3,
+	}}
+	result, total, groups, err := SolveWithInterchangeability(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if fmt.Sprint(groups) != "[[0 2]]" {
+		t.Fatalf("interchangeable groups %v, want [[0 2]]", groups)
+	}
+	//swapping the columns of the grouped rows leaves the total unchanged
+	perm := []int64{result[2].Col, result[1].Col, result[0].Col}
+	if swapped, _ := m.PermutationCost(perm); swapped != total {
+		t.Fatalf("swapped assignment costs %v, optimum %v", swapped, total)
+	}
+}
+
+func TestSolveWithSink(t *testing.T) {
+	r := rand.New(rand.NewSource(29))
+	for trial := 0; trial < 20; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(8)))
+		var collected []Assignment
+		total, err := SolveWithSink(m, func(row, col int64, cost float64) {
+			collected = append(collected, Assignment{Row: row, Col: col, Cost: cost})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAssignment(t, m, collected, total)
+		want, _, _ := Solve(m)
+		if fmt.Sprint(collected) != fmt.Sprint(want) {
+			t.Fatalf("trial %d: sink saw %v, Solve returned %v", trial, collected, want)
+		}
+	}
+	calls := 0
+	sink := func(int64, int64, float64) { calls++ }
+	if _, err := SolveWithSink(&FloatMatrix{N: 1, A: []float64{math.Inf(1)}}, sink); !errors.Is(err, ErrInfeasible) || calls != 0 {
+		t.Fatalf("infeasible matrix: err = %v after %d calls", err, calls)
+	}
+}
+
+func TestSolveFixedPoint(t *testing.T) {
+	r := rand.New(rand.NewSource(35))
+	const scale = 1000
+	for trial := 0; trial < 30; trial++ {
+		m := NewMatrix(int64(1 + r.Intn(7)))
+		for idx := range m.A {
+			m.A[idx] = r.Float64() * 100
+		}
+		result, total, err := SolveFixedPoint(m, scale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, exact, _ := Solve(m)
+		//each of the N chosen cells is off by at most 0.5/scale, and so is the optimum it competes with
+		if tol := float64(2*m.N) * 0.5 / scale; math.Abs(total-exact) > tol {
+			t.Fatalf("trial %d: fixed point total %v, float total %v", trial, total, exact)
+		}
+		var sum float64
+		for _, a := range result {
+			sum += a.Cost
+			if units := a.Cost * scale; math.Abs(units-math.Round(units)) > 1e-6 {
+				t.Fatalf("trial %d: cost %v is not a multiple of 1/scale", trial, a.Cost)
+			}
+		}
+		if math.Abs(sum-total) > 1e-9 {
+			t.Fatalf("trial %d: pairs sum to %v, total is %v", trial, sum, total)
+		}
+	}
+	if _, _, err := SolveFixedPoint(NewMatrix(2), 0); err == nil {
+		t.Fatal("zero scale accepted")
+	}
+	if _, _, err := SolveFixedPoint(&FloatMatrix{N: 2, A: []float64{1e300, 0, 0, 0}}, 1); err == nil {
+		t.Fatal("costs beyond the exact integer range accepted")
+	}
+}
+
+func TestSolveWithPaths(t *testing.T) {
+	r := rand.New(rand.NewSource(36))
+	found := 0
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(10)))
+		paths, total := SolveWithPaths(m)
+		if want := GetMunkresMinScore(m); total != want {
+			t.Fatalf("trial %d: total %v, want %v", trial, total, want)
+		}
+		if int64(len(paths)) > m.N {
+			t.Fatalf("trial %d: %d paths for n=%d", trial, len(paths), m.N)
+		}
+		found += len(paths)
+		for _, path := range paths {
+			//primed zeros sit at even positions and stars at odd ones, so a path that ends at a primed zero is odd in
+			//length; each star shares a column with the prime before it and a row with the prime after it
+			if len(path)%2 != 1 {
+				t.Fatalf("trial %d: path %v has even length", trial, path)
+			}
+			for k := 1; k < len(path); k++ {
+				if k%2 == 1 && path[k][1] != path[k-1][1] || k%2 == 0 && path[k][0] != path[k-1][0] {
+					t.Fatalf("trial %d: path %v breaks alternation at %d", trial, path, k)
+				}
+			}
+		}
+		//the primes of the last path become stars and nothing moves them afterwards
+		if len(paths) > 0 {
+			_, perm := SolveScoreAndPerm(m)
+			last := paths[len(paths)-1]
+			for k := 0; k < len(last); k += 2 {
+				if perm[last[k][0]] != last[k][1] {
+					t.Fatalf("trial %d: prime %v of the last path is not in the assignment %v", trial, last[k], perm)
+				}
+			}
+		}
+	}
+	if found == 0 {
+		t.Fatal("no solve needed an augmenting path")
+	}
+}
+
+func TestSolveWithPreferences(t *testing.T) {
+	//both assignments cost 0; column order stars the diagonal, the preference the anti-diagonal
+	m := NewMatrix(2)
+	result, _, err := SolveWithPreferences(m, [][]int64{nil, nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result[0].Col != 0 {
+		t.Fatalf("without preferences: %v, want row 0 in column 0", result)
+	}
+	result, total, err := SolveWithPreferences(m, [][]int64{{1}, {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result[0].Col != 1 || result[1].Col != 0 || total != 0 {
+		t.Fatalf("preferring column 1 for row 0: %v totalling %v", result, total)
+	}
+	//a preference for a costlier cell cannot beat the optimum
+	m = &FloatMatrix{N: 2, A: []float64{0, 1, 0, 0}}
+	if result, total, _ = SolveWithPreferences(m, [][]int64{{1}, {1}}); total != 0 || result[0].Col != 0 {
+		t.Fatalf("preferring a costlier cell: %v totalling %v", result, total)
+	}
+	if _, _, err := SolveWithPreferences(m, [][]int64{{2}, nil}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("out of range preference: err = %v, want ErrOutOfRange", err)
+	}
+	if _, _, err := SolveWithPreferences(m, nil); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("missing preference lists: err = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestSolveVariants(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	base := randomMatrix(r, 8)
+	patches := make([]map[[2]int64]float64, 20)
+	for k := range patches {
+		patches[k] = map[[2]int64]float64{}
+		for c := 0; c < 1+r.Intn(4); c++ {
+			patches[k][[2]int64{int64(r.Intn(8)), int64(r.Intn(8))}] = float64(r.Intn(50))
+		}
+	}
+	patches = append(patches, map[[2]int64]float64{{8, 0}: 1}, map[[2]int64]float64{{0, 0}: math.NaN()})
+	totals := SolveVariants(base, patches)
+	if len(totals) != len(patches) {
+		t.Fatalf("%d totals for %d patches", len(totals), len(patches))
+	}
+	for k, patch := range patches[:20] {
+		variant := &FloatMatrix{N: base.N, A: append([]float64(nil), base.A...)}
+		for cell, v := range patch {
+			variant.SetElement(cell[0], cell[1], v)
+		}
+		if want := GetMunkresMinScore(variant); totals[k] != want {
+			t.Fatalf("variant %d: total %v, cold solve %v", k, totals[k], want)
+		}
+	}
+	for k := 20; k < len(totals); k++ {
+		if !math.IsInf(totals[k], 1) {
+			t.Errorf("invalid variant %d: total %v, want +Inf", k, totals[k])
+		}
+	}
+}
+
+func TestSolveVariantsMalformedBase(t *testing.T) {
+	short := &FloatMatrix{N: 2, A: []float64{1, 2, 3}}
+	totals := SolveVariants(short, []map[[2]int64]float64{{}, {{0, 0}: 1}})
+	if len(totals) != 2 || !math.IsInf(totals[0], 1) || !math.IsInf(totals[1], 1) {
+		t.Fatalf("non-square base: totals %v, want [+Inf +Inf]", totals)
+	}
+	//a NaN in the base only costs the warm start; a patch that overwrites it yields a valid variant
+	withNaN := &FloatMatrix{N: 2, A: []float64{math.NaN(), 2, 3, 1}}
+	totals = SolveVariants(withNaN, []map[[2]int64]float64{{}, {{0, 0}: 1}})
+	if !math.IsInf(totals[0], 1) || totals[1] != 2 {
+		t.Fatalf("base with a NaN: totals %v, want [+Inf 2]", totals)
+	}
+}
+
+func TestSolveTreatNaNAsForbidden(t *testing.T) {
+	nan := math.NaN()
+	m := &FloatMatrix{N: 3, A: []float64{
+		nan, 1, 5,
+		2, nan, 1,
+		1, 3, nan,
+	}}
+	result, total, err := SolveTreatNaNAsForbidden(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range result {
+		if math.IsNaN(a.Cost) {
+			t.Fatalf("chose the NaN cell (%d,%d)", a.Row, a.Col)
+		}
+	}
+	if total != 3 {
+		t.Fatalf("total %v, want 3", total)
+	}
+	if _, _, err := SolveTreatNaNAsForbidden(&FloatMatrix{N: 2, A: []float64{nan, nan, 1, 2}}); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("a row of NaNs: err = %v, want ErrInfeasible", err)
+	}
+	if _, _, err := Solve(m); err == nil {
+		t.Fatal("plain Solve accepted NaN cells")
+	}
+}
+
+//fixtureFloat evaluates an element of a captured fixture, which is either a float literal, possibly negated, or a
+//math.Inf or math.NaN call
+func fixtureFloat(e ast.Expr) (float64, error) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		return strconv.ParseFloat(e.Value, 64)
+	case *ast.UnaryExpr:
+		v, err := fixtureFloat(e.X)
+		if e.Op == token.SUB {
+			v = -v
+		}
+		return v, err
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		switch sel.Sel.Name {
+		case "NaN":
+			return math.NaN(), nil
+		case "Inf":
+			sign, err := fixtureFloat(e.Args[0])
+			return math.Inf(int(sign)), err
+		}
+	}
+	return 0, fmt.Errorf("unexpected fixture element %T", e)
+}
+
+//capturedMatrix parses fixture as Go source and returns the matrix its composite literal describes
+func capturedMatrix(t *testing.T, fixture string) *FloatMatrix {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "fixture.go", "package fixture\n\n"+fixture, 0)
+	if err != nil {
+		t.Fatalf("fixture does not parse: %v\n%s", err, fixture)
+	}
+	m := &FloatMatrix{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		switch kv.Key.(*ast.Ident).Name {
+		case "N":
+			m.N, err = strconv.ParseInt(kv.Value.(*ast.BasicLit).Value, 10, 64)
+		case "A":
+			for _, e := range kv.Value.(*ast.CompositeLit).Elts {
+				v, verr := fixtureFloat(e)
+				if verr != nil {
+					err = verr
+				}
+				m.A = append(m.A, v)
+			}
+		}
+		return false
+	})
+	if err != nil {
+		t.Fatalf("fixture: %v\n%s", err, fixture)
+	}
+	return m
+}
+
+func TestSolveWithCapture(t *testing.T) {
+	m := NewMatrix(3)
+	m.A = []float64{4, -1.5, math.Inf(1), 2, 0.1, 7, 3, 1e-9, 5}
+	score, capture := SolveWithCapture(m)
+	if want := GetMunkresMinScore(m); score != want {
+		t.Fatalf("SolveWithCapture = %v, want %v", score, want)
+	}
+	input := append([]float64(nil), m.A...)
+	m.SetElement(0, 0, 100)
+	fixture := capture()
+	got := capturedMatrix(t, fixture)
+	if got.N != 3 || !equalFloats(got.A, input) {
+		t.Fatalf("fixture reproduces %v, want %v\n%s", got.A, input, fixture)
+	}
+	if !strings.Contains(fixture, "GetMunkresMinScore(m); got != "+strconv.FormatFloat(score, 'g', -1, 64)) {
+		t.Fatalf("fixture does not assert the score %v:\n%s", score, fixture)
+	}
+
+	bad := &FloatMatrix{N: 2, A: []float64{1, math.NaN(), 3, 4}}
+	score, capture = SolveWithCapture(bad)
+	if !math.IsNaN(score) {
+		t.Fatalf("invalid matrix: score = %v, want NaN", score)
+	}
+	fixture = capture()
+	got = capturedMatrix(t, fixture)
+	if got.N != 2 || len(got.A) != 4 || !math.IsNaN(got.A[1]) || got.A[3] != 4 {
+		t.Fatalf("fixture reproduces %v\n%s", got.A, fixture)
+	}
+	if !strings.Contains(fixture, "m.Validate(); err == nil") {
+		t.Fatalf("fixture for an invalid matrix does not assert validation fails:\n%s", fixture)
+	}
+}
+
+func TestGetMunkresMaxAssignments(t *testing.T) {
+	r := rand.New(rand.NewSource(188))
+	for trial := 0; trial < 50; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(6)))
+		negated := NewMatrix(m.N)
+		for idx, v := range m.A {
+			negated.A[idx] = -v
+		}
+		want := -bruteForceMin(negated)
+		result, total := GetMunkresMaxAssignments(m)
+		checkAssignment(t, m, result, total)
+		if total != want {
+			t.Fatalf("trial %d: total %v, want %v", trial, total, want)
+		}
+		if score := GetMunkresMaxScore(m); score != total {
+			t.Fatalf("trial %d: GetMunkresMaxScore = %v, assignments total %v", trial, score, total)
+		}
+	}
+	m := &FloatMatrix{N: 2, A: []float64{math.Inf(1), 1, math.Inf(1), 2}}
+	if result, total := GetMunkresMaxAssignments(m); result != nil || !math.IsInf(total, -1) {
+		t.Fatalf("infeasible matrix: %v, %v, want nil, -Inf", result, total)
+	}
+}
+
+func TestGetMunkresLabeled(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{
+		9, 1, 9,
+		9, 9, 1,
+		1, 9, 9,
+	}}
+	workers := []string{"ann", "bob", "cy"}
+	tasks := []string{"build", "test", "ship"}
+	pairs, err := GetMunkresLabeled(m, workers, tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]string{{"ann", "test"}, {"bob", "ship"}, {"cy", "build"}}
+	if fmt.Sprint(pairs) != fmt.Sprint(want) {
+		t.Fatalf("GetMunkresLabeled = %v, want %v", pairs, want)
+	}
+	if _, err := GetMunkresLabeled(m, workers[:2], tasks); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("short row labels: err = %v, want ErrDimensionMismatch", err)
+	}
+	if _, err := GetMunkresLabeled(m, workers, append(tasks, "extra")); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("long column labels: err = %v, want ErrDimensionMismatch", err)
+	}
+}
@@ -0,0 +1,264 @@
+package munkres
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+//Solver solves cost matrices one after another and exposes hooks into the running algorithm.
+//The zero value is ready to use.
+type Solver struct {
+	//OnStep, if set, is called after every step of the algorithm with that step's number (1 through 6)
+	OnStep func(step int)
+
+	forbidden    float64
+	hasForbidden bool
+	warm         [][2]int64
+	floor        float64
+	hasFloor     bool
+	reduction    ReductionOrder
+	rescale      bool
+	scaleExp     int
+	less         func(a, b float64) bool
+	isZero       func(float64) bool
+	ctx          *context
+	last         *FloatMatrix
+}
+
+//Option configures a Solver created by NewSolver
+type Option func(*Solver)
+
+//NewSolver returns a Solver configured with opts
+func NewSolver(opts ...Option) *Solver {
+	s := new(Solver)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+//WithForbiddenSentinel makes the solver treat every cell >= v as forbidden, just like +Inf.
+//This suits callers whose cost data passes through formats such as JSON that cannot carry Inf.
+func WithForbiddenSentinel(v float64) Option {
+	return func(s *Solver) {
+		s.forbidden = v
+		s.hasForbidden = true
+	}
+}
+
+//WithWarmStart stars the given (row, col) pairs before the greedy starring of step 2, steering the solver toward a
+//known good matching; the saved work shows up as fewer step 5 augmentations in Stats. The pairs must not share rows or
+//columns. Pairs that are not zero after row reduction cannot be starred and are ignored, so the result is optimal even
+//when the warm start is not.
+func WithWarmStart(pairs [][2]int64) Option {
+	return func(s *Solver) {
+		s.warm = pairs
+	}
+}
+
+//WithReducedFloor clamps every reduced cost produced by step 6 to at least floor, compared with the ordering given to
+//WithComparator if any. Step 6 never produces a negative reduced cost in exact arithmetic, so the clamp only absorbs
+//rounding error, stopping it from drifting values far below zero on very large matrices. The floor is in the units of
+//the input costs and is rescaled along with them under WithRescale. A positive floor would erase the zeros step 6
+//creates, so it is treated as zero. A clamp raises a single cell, which no row or column potential can express, so
+//once one fires the reduced matrix is no longer exactly the costs minus the potentials and CurrentDualValue is only
+//approximate, off by at most the rounding error absorbed.
+func WithReducedFloor(floor float64) Option {
+	return func(s *Solver) {
+		s.floor = math.Min(floor, 0)
+		s.hasFloor = true
+	}
+}
+
+//WithReductionOrder chooses the reductions applied by step 1. Every order gives the same optimal total, though the
+//assignment may differ between equally cheap ones, but the number of later steps needed varies with the input.
+func WithReductionOrder(order ReductionOrder) Option {
+	return func(s *Solver) {
+		s.reduction = order
+	}
+}
+
+//WithRescale divides the working matrix by the power of two nearest above its largest finite magnitude before
+//solving, bringing every cost into [-1, 1]. Scaling by a power of two is exact, so the assignment is unaffected and
+//the reported costs, always read from the original matrix, need no conversion back. Floating point error is relative,
+//so rescaling cannot recover precision lost when tiny and huge costs are mixed; what it does prevent is step 6
+//overflowing to +Inf when costs approach the largest float64.
+func WithRescale() Option {
+	return func(s *Solver) {
+		s.rescale = true
+	}
+}
+
+//WithComparator replaces the ordering and zero test the steps apply to reduced costs, for costs with their own
+//semantics wrapped in float64. less must be a strict ordering and isZero must accept the zeros step 6 creates by
+//subtracting equal values, or the solver may not terminate. Either may be nil to keep the standard
+//comparison. An isZero that accepts values within an epsilon of zero trades exactness for fewer steps; the result can
+//then exceed the optimum by up to N times that epsilon.
+func WithComparator(less func(a, b float64) bool, isZero func(float64) bool) Option {
+	return func(s *Solver) {
+		s.less = less
+		s.isZero = isZero
+	}
+}
+
+//Solve validates m and returns the lowest cost assignment along with its total cost
+func (s *Solver) Solve(m *FloatMatrix) ([]Assignment, float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if err := checkPartialMatching(s.warm, m.N); err != nil {
+		return nil, 0, err
+	}
+	s.ctx = newContext(m)
+	s.ctx.warm = s.warm
+	if s.hasForbidden {
+		for idx, v := range s.ctx.m.A {
+			if v >= s.forbidden {
+				s.ctx.m.A[idx] = math.Inf(1)
+			}
+		}
+	}
+	s.scaleExp = 0
+	if s.rescale {
+		s.scaleExp = rescale(s.ctx.m)
+	}
+	return s.run(&FloatMatrix{N: m.N, A: append([]float64(nil), m.A...)})
+}
+
+//run applies the remaining options to s.ctx and solves it, reading costs from m. On success m is kept for
+//RemoveRowCol, which must not see the caller's matrix change underneath it.
+func (s *Solver) run(m *FloatMatrix) ([]Assignment, float64, error) {
+	s.last = nil
+	s.ctx.floor, s.ctx.hasFloor = math.Ldexp(s.floor, -s.scaleExp), s.hasFloor
+	s.ctx.reduction = s.reduction
+	s.ctx.lessFn, s.ctx.isZeroFn = s.less, s.isZero
+	if s.OnStep != nil {
+		s.ctx.onStep = func(stp step) {
+			s.OnStep(stepNumber(stp))
+		}
+	}
+	if err := s.ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	s.last = m
+	return assignments(m, s.ctx.assignment()), s.ctx.score(m), nil
+}
+
+//RemoveRowCol withdraws row i and column j from the most recent successful solve, such as a worker and a task that are
+//no longer available, and solves the smaller problem. It starts from the previous reduced matrix, whose potentials
+//remain valid once a row and a column are gone, and from the previous assignment minus the pairs in row i and column j,
+//so usually a single augmentation completes it. RemoveRowCol can be called repeatedly; each call shrinks the problem
+//left by the one before. The result is reported with indices of the smaller problem, in which later rows and columns
+//move up by one.
+func (s *Solver) RemoveRowCol(i, j int64) ([]Assignment, float64, error) {
+	if s.last == nil {
+		return nil, 0, errors.New("munkres: RemoveRowCol needs a successful solve to shrink")
+	}
+	n := s.last.N
+	if i < 0 || j < 0 || i >= n || j >= n {
+		return nil, 0, fmt.Errorf("munkres: removing row %d and column %d of a matrix of size %d: %w", i, j, n, ErrOutOfRange)
+	}
+	prev := s.ctx
+	s.ctx = newContext(withoutRowCol(prev.m, i, j))
+	s.ctx.rowDual = append(append([]float64(nil), prev.rowDual[:i]...), prev.rowDual[i+1:]...)
+	s.ctx.colDual = append(append([]float64(nil), prev.colDual[:j]...), prev.colDual[j+1:]...)
+	for r, c := range prev.assignment() {
+		row := int64(r)
+		if row == i || c == j {
+			continue
+		}
+		if row > i {
+			row--
+		}
+		if c > j {
+			c--
+		}
+		s.ctx.warm = append(s.ctx.warm, [2]int64{row, c})
+	}
+	return s.run(withoutRowCol(s.last, i, j))
+}
+
+//withoutRowCol returns a copy of m with row i and column j deleted
+func withoutRowCol(m *FloatMatrix, i, j int64) *FloatMatrix {
+	n := m.N
+	result := NewMatrix(n - 1)
+	k := 0
+	for r := zero64; r < n; r++ {
+		for c := zero64; c < n; c++ {
+			if r != i && c != j {
+				result.A[k] = m.GetElement(r, c)
+				k++
+			}
+		}
+	}
+	return result
+}
+
+//rescale divides every finite element of m by the power of two nearest above its largest finite magnitude and returns
+//that power's exponent
+func rescale(m *FloatMatrix) int {
+	var largest float64
+	for _, v := range m.A {
+		if !math.IsInf(v, 0) {
+			largest = math.Max(largest, math.Abs(v))
+		}
+	}
+	if largest == 0 {
+		return 0
+	}
+	_, exp := math.Frexp(largest)
+	for idx, v := range m.A {
+		m.A[idx] = math.Ldexp(v, -exp)
+	}
+	return exp
+}
+
+//checkPartialMatching verifies that pairs lie inside an n by n matrix and share no rows or columns
+func checkPartialMatching(pairs [][2]int64, n int64) error {
+	rowUsed := make([]bool, n)
+	colUsed := make([]bool, n)
+	for _, p := range pairs {
+		i, j := p[0], p[1]
+		if i < 0 || j < 0 || i >= n || j >= n {
+			return fmt.Errorf("munkres: warm start pair (%d,%d) of a matrix of size %d: %w", i, j, n, ErrOutOfRange)
+		}
+		if rowUsed[i] || colUsed[j] {
+			return fmt.Errorf("munkres: warm start pair (%d,%d) shares a row or column with another pair", i, j)
+		}
+		rowUsed[i] = true
+		colUsed[j] = true
+	}
+	return nil
+}
+
+//Stats returns counters describing the current or most recent solve
+func (s *Solver) Stats() SolveStats {
+	if s.ctx == nil {
+		return SolveStats{}
+	}
+	return s.ctx.stats
+}
+
+//ReducedMatrix returns a copy of the working matrix of the current or most recent solve, or nil before the first solve.
+//It is safe to call from OnStep; changes to the copy do not affect the solver.
+func (s *Solver) ReducedMatrix() *FloatMatrix {
+	if s.ctx == nil {
+		return nil
+	}
+	reduced := NewMatrix(s.ctx.m.N)
+	copy(reduced.A, s.ctx.m.A)
+	return reduced
+}
+
+//CurrentDualValue returns the sum of the row and column potentials of the current or most recent solve: the amount
+//step 1 and every step 6 so far have subtracted from the cost of every complete assignment. It is a lower bound on the
+//optimal total that never decreases from one step to the next and equals the optimum once the solve completes, so
+//calling it from OnStep traces the gap closing. It returns 0 before the first solve. Under WithReducedFloor the value
+//is approximate once a clamp has fired.
+func (s *Solver) CurrentDualValue() float64 {
+	if s.ctx == nil {
+		return 0
+	}
+	return math.Ldexp(s.ctx.dualValue(), s.scaleExp)
+}
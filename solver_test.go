@@ -0,0 +1,397 @@
+package munkres
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSolverReducedMatrix(t *testing.T) {
+	var s Solver
+	if s.ReducedMatrix() != nil {
+		t.Fatal("ReducedMatrix before the first solve is not nil")
+	}
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	var sums []float64
+	s.OnStep = func(step int) {
+		reduced := s.ReducedMatrix()
+		if step == 1 {
+			for i := int64(0); i < reduced.N; i++ {
+				rowMin := math.Inf(1)
+				for j := int64(0); j < reduced.N; j++ {
+					rowMin = math.Min(rowMin, reduced.GetElement(i, j))
+				}
+				if rowMin != 0 {
+					t.Fatalf("row %d of the reduced matrix after step 1 has minimum %v", i, rowMin)
+				}
+			}
+		}
+		var sum float64
+		for _, v := range reduced.A {
+			sum += v
+		}
+		sums = append(sums, sum)
+		//scribbling on the copy must not disturb the solve
+		reduced.A[0] = -100
+	}
+	_, total, err := s.Solve(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 5 {
+		t.Fatalf("total = %v, want 5", total)
+	}
+	if len(sums) == 0 || sums[0] >= 22 {
+		t.Fatalf("reduced sums %v do not start below the input sum 22", sums)
+	}
+	if m.A[0] != 4 {
+		t.Fatal("the solve changed its input")
+	}
+}
+
+func TestSolverForbiddenSentinel(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for trial := 0; trial < 100; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(5)))
+		withInf := NewMatrix(m.N)
+		for idx, v := range m.A {
+			if v >= 40 {
+				withInf.A[idx] = math.Inf(1)
+			} else {
+				withInf.A[idx] = v
+			}
+		}
+		_, got, gotErr := NewSolver(WithForbiddenSentinel(40)).Solve(m)
+		_, want, wantErr := Solve(withInf)
+		if got != want || gotErr != wantErr {
+			t.Fatalf("trial %d: sentinel gave %v, %v; +Inf gave %v, %v", trial, got, gotErr, want, wantErr)
+		}
+	}
+}
+
+func TestSolverForbiddenSentinelAvoidsCell(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{0, 1, 1, 1e18}}
+	result, total, err := NewSolver(WithForbiddenSentinel(1e18)).Solve(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || result[1].Col != 0 {
+		t.Fatalf("chose %v totalling %v, want the off-diagonal pairs", result, total)
+	}
+}
+
+func TestSolverStatsInitialStarComplete(t *testing.T) {
+	var s Solver
+	identity := &FloatMatrix{N: 3, A: []float64{0, 1, 2, 3, 0, 4, 5, 6, 0}}
+	if _, _, err := s.Solve(identity); err != nil {
+		t.Fatal(err)
+	}
+	if stats := s.Stats(); !stats.InitialStarComplete || stats.StepCounts[5] != 0 {
+		t.Fatalf("identity-optimal matrix: %+v", stats)
+	}
+	hard := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	if _, _, err := s.Solve(hard); err != nil {
+		t.Fatal(err)
+	}
+	if stats := s.Stats(); stats.InitialStarComplete || stats.StepCounts[5] == 0 {
+		t.Fatalf("matrix needing step 6: %+v", stats)
+	}
+}
+
+func TestSolverWarmStart(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+	var cold, warm int
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, 12)
+		var s Solver
+		result, want, err := s.Solve(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cold += s.Stats().StepCounts[4] + s.Stats().StepCounts[5]
+		pairs := make([][2]int64, len(result))
+		for k, a := range result {
+			pairs[k] = [2]int64{a.Row, a.Col}
+		}
+		ws := NewSolver(WithWarmStart(pairs))
+		if _, total, err := ws.Solve(m); err != nil || total != want {
+			t.Fatalf("trial %d: warm start gave %v, %v, want %v", trial, total, err, want)
+		}
+		warm += ws.Stats().StepCounts[4] + ws.Stats().StepCounts[5]
+	}
+	if warm >= cold {
+		t.Fatalf("warm starts from the optimum ran %d augmentations and step 6 passes, cold starts %d", warm, cold)
+	}
+}
+
+func TestSolverWarmStartSuboptimal(t *testing.T) {
+	m := &FloatMatrix{N: 3, A: []float64{4, 1, 3, 2, 0, 5, 3, 2, 2}}
+	//the identity costs 6 against the optimum of 5
+	s := NewSolver(WithWarmStart([][2]int64{{0, 0}, {1, 1}, {2, 2}}))
+	result, total, err := s.Solve(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if total != 5 {
+		t.Fatalf("total = %v, want 5", total)
+	}
+	for _, pairs := range [][][2]int64{{{0, 0}, {0, 1}}, {{0, 2}, {1, 2}}, {{0, 3}}} {
+		if _, _, err := NewSolver(WithWarmStart(pairs)).Solve(m); err == nil {
+			t.Errorf("warm start %v accepted", pairs)
+		}
+	}
+}
+
+func TestSolverStatsStep6Zeros(t *testing.T) {
+	r := rand.New(rand.NewSource(16))
+	var s Solver
+	for trial := 0; trial < 20; trial++ {
+		if _, _, err := s.Solve(randomMatrix(r, 10)); err != nil {
+			t.Fatal(err)
+		}
+		stats := s.Stats()
+		if len(stats.Step6Zeros) != stats.StepCounts[5] {
+			t.Fatalf("trial %d: %d zero counts for %d runs of step 6", trial, len(stats.Step6Zeros), stats.StepCounts[5])
+		}
+		for k, zeros := range stats.Step6Zeros {
+			if zeros <= 0 {
+				t.Fatalf("trial %d: step 6 run %d created %d zeros", trial, k, zeros)
+			}
+		}
+	}
+}
+
+func TestSolverReducedFloor(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(10)))
+		_, want, err := Solve(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//a positive floor is treated as zero, so it must not erase the zeros step 6 creates either
+		for _, floor := range []float64{-1e-9, 0, 1} {
+			result, total, err := NewSolver(WithReducedFloor(floor)).Solve(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkAssignment(t, m, result, total)
+			if total != want {
+				t.Fatalf("trial %d, floor %v: total %v, want %v", trial, floor, total, want)
+			}
+		}
+	}
+}
+
+func TestSolverReducedFloorRescaled(t *testing.T) {
+	r := rand.New(rand.NewSource(137))
+	m := randomMatrix(r, 6)
+	for idx := range m.A {
+		m.A[idx] *= 1e6
+	}
+	s := NewSolver(WithRescale(), WithReducedFloor(-1e-3))
+	result, total, err := s.Solve(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if want := bruteForceMin(m); total != want {
+		t.Fatalf("total %v, want %v", total, want)
+	}
+	//the floor is in input units, so it must shrink with the costs it is compared against
+	if want := math.Ldexp(-1e-3, -s.scaleExp); s.ctx.floor != want || s.scaleExp == 0 {
+		t.Fatalf("working floor %v at scale exponent %d, want %v", s.ctx.floor, s.scaleExp, want)
+	}
+	if s.CurrentDualValue() != total {
+		t.Fatalf("CurrentDualValue = %v without any clamp firing, want %v", s.CurrentDualValue(), total)
+	}
+}
+
+func TestStep6FloorUsesComparator(t *testing.T) {
+	//under this ordering every value is below the floor, so each cell step 6 visits is raised to it
+	ctx := newContext(&FloatMatrix{N: 2, A: []float64{0, 3, 2, 5}})
+	ctx.hasFloor, ctx.floor = true, -1
+	ctx.lessFn = func(a, b float64) bool { return true }
+	ctx.rowCovered[0] = true
+	step6{}.compute(ctx)
+	if !equalFloats(ctx.m.A, []float64{-1, -1, -1, -1}) {
+		t.Fatalf("after step 6 the matrix is %v, want every cell clamped to -1", ctx.m.A)
+	}
+}
+
+func TestSolverReductionOrders(t *testing.T) {
+	r := rand.New(rand.NewSource(26))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(10)))
+		want := bruteForceMin(m)
+		if m.N > 7 {
+			want = GetMunkresMinScore(m)
+		}
+		for _, order := range []ReductionOrder{RowsFirst, ColumnsFirst, Both} {
+			result, total, err := NewSolver(WithReductionOrder(order)).Solve(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkAssignment(t, m, result, total)
+			if total != want {
+				t.Fatalf("trial %d, order %d: total %v, want %v", trial, order, total, want)
+			}
+		}
+	}
+}
+
+//BenchmarkReductionOrder reports the steps each order needs on the same inputs alongside the time taken
+func BenchmarkReductionOrder(b *testing.B) {
+	r := rand.New(rand.NewSource(27))
+	m := NewMatrix(100)
+	for idx := range m.A {
+		m.A[idx] = r.Float64()
+	}
+	for _, c := range []struct {
+		name  string
+		order ReductionOrder
+	}{{"RowsFirst", RowsFirst}, {"ColumnsFirst", ColumnsFirst}, {"Both", Both}} {
+		b.Run(c.name, func(b *testing.B) {
+			s := NewSolver(WithReductionOrder(c.order))
+			for i := 0; i < b.N; i++ {
+				s.Solve(m)
+			}
+			var steps int
+			for _, count := range s.Stats().StepCounts {
+				steps += count
+			}
+			b.ReportMetric(float64(steps), "steps/op")
+		})
+	}
+}
+
+func TestSolverRescale(t *testing.T) {
+	r := rand.New(rand.NewSource(30))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(6)))
+		for idx := range m.A {
+			if r.Intn(2) == 0 {
+				m.A[idx] *= 1e-9
+			} else {
+				m.A[idx] *= 1e9
+			}
+		}
+		result, total, err := NewSolver(WithRescale()).Solve(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAssignment(t, m, result, total)
+		if want := bruteForceMin(m); total != want {
+			t.Fatalf("trial %d: rescaled total %v, want %v", trial, total, want)
+		}
+	}
+}
+
+func TestSolverComparatorEpsilonZero(t *testing.T) {
+	const eps = 1e-9
+	m := &FloatMatrix{N: 2, A: []float64{1e-10, 0, 0, 1e-10}}
+	_, exact, err := NewSolver().Solve(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exact != 0 {
+		t.Fatalf("exact comparison: total %v, want 0", exact)
+	}
+	//treating 1e-10 as zero lets step 2 star the diagonal and stop there
+	nearZero := func(v float64) bool { return math.Abs(v) <= eps }
+	result, total, err := NewSolver(WithComparator(nil, nearZero)).Solve(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAssignment(t, m, result, total)
+	if total != 2e-10 {
+		t.Fatalf("epsilon zero test: total %v, want the diagonal's 2e-10", total)
+	}
+	if total > exact+float64(m.N)*eps {
+		t.Fatalf("total %v exceeds the optimum %v by more than N*eps", total, exact)
+	}
+}
+
+func TestSolverComparatorDefaults(t *testing.T) {
+	r := rand.New(rand.NewSource(33))
+	less := func(a, b float64) bool { return a < b }
+	isZero := func(v float64) bool { return v == 0 }
+	for trial := 0; trial < 20; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(8)))
+		_, want, _ := Solve(m)
+		for _, s := range []*Solver{NewSolver(WithComparator(less, isZero)), NewSolver(WithComparator(nil, nil))} {
+			if _, total, err := s.Solve(m); err != nil || total != want {
+				t.Fatalf("trial %d: standard comparator gave %v, %v, want %v", trial, total, err, want)
+			}
+		}
+	}
+}
+
+func TestSolverCurrentDualValue(t *testing.T) {
+	var s Solver
+	if v := s.CurrentDualValue(); v != 0 {
+		t.Fatalf("CurrentDualValue before the first solve = %v, want 0", v)
+	}
+	r := rand.New(rand.NewSource(187))
+	for trial := 0; trial < 60; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(7)))
+		s := NewSolver()
+		if trial%2 == 1 {
+			s = NewSolver(WithRescale())
+		}
+		want := bruteForceMin(m)
+		var duals []float64
+		s.OnStep = func(step int) {
+			dual := s.CurrentDualValue()
+			if len(duals) > 0 && dual < duals[len(duals)-1] {
+				t.Fatalf("trial %d: dual value fell from %v to %v after step %d", trial, duals[len(duals)-1], dual, step)
+			}
+			if dual > want {
+				t.Fatalf("trial %d: dual value %v after step %d exceeds the optimum %v", trial, dual, step, want)
+			}
+			duals = append(duals, dual)
+		}
+		_, total, err := s.Solve(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != want || s.CurrentDualValue() != want {
+			t.Fatalf("trial %d: total %v and final dual value %v, want %v", trial, total, s.CurrentDualValue(), want)
+		}
+		if len(duals) == 0 || duals[len(duals)-1] != want {
+			t.Fatalf("trial %d: dual values %v do not reach the optimum %v", trial, duals, want)
+		}
+	}
+}
+
+func TestSolverRemoveRowCol(t *testing.T) {
+	var s Solver
+	if _, _, err := s.RemoveRowCol(0, 0); err == nil {
+		t.Fatal("RemoveRowCol before a solve succeeded")
+	}
+	r := rand.New(rand.NewSource(200))
+	for trial := 0; trial < 30; trial++ {
+		m := randomMatrix(r, int64(2+r.Intn(6)))
+		s := NewSolver()
+		if _, _, err := s.Solve(m); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := s.RemoveRowCol(m.N, 0); !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("trial %d: removing row %d: err = %v, want ErrOutOfRange", trial, m.N, err)
+		}
+		for m.N > 1 {
+			i, j := r.Int63n(m.N), r.Int63n(m.N)
+			m = withoutRowCol(m, i, j)
+			result, total, err := s.RemoveRowCol(i, j)
+			if err != nil {
+				t.Fatalf("trial %d: removing (%d,%d): %v", trial, i, j, err)
+			}
+			checkAssignment(t, m, result, total)
+			if want := bruteForceMin(m); total != want {
+				t.Fatalf("trial %d: after removing (%d,%d) total %v, cold solve %v", trial, i, j, total, want)
+			}
+		}
+	}
+}
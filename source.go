@@ -0,0 +1,93 @@
+package munkres
+
+import "time"
+
+//CostSource is a read-only square matrix of costs that can be read one cell at a time
+type CostSource interface {
+	//Size returns N, the number of rows and columns
+	Size() int64
+	//Cost returns the cost at row i, column j
+	Cost(i, j int64) float64
+}
+
+//SolveSource solves src like Solve. The algorithm reduces its costs in place, so every cell of src is read once into
+//an N by N working matrix on the heap, which is solved directly rather than copied again as Solve would. The costs
+//reported for the chosen cells are read back from src, which itself is never written. A source that keeps its cells
+//off the heap, such as an MmapMatrix, thus saves the caller's copy of the matrix but not the working one.
+func SolveSource(src CostSource) ([]Assignment, float64, error) {
+	n := src.Size()
+	m := NewMatrix(n)
+	for i := zero64; i < n; i++ {
+		for j := zero64; j < n; j++ {
+			m.SetElement(i, j, src.Cost(i, j))
+		}
+	}
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	ctx := newContextOwning(m)
+	if err := ctx.run(); err != nil {
+		return nil, 0, err
+	}
+	perm := ctx.assignment()
+	result := make([]Assignment, n)
+	var total float64
+	for i, j := range perm {
+		result[i] = Assignment{Row: int64(i), Col: j, Cost: src.Cost(int64(i), j)}
+		total += result[i].Cost
+	}
+	return result, total, nil
+}
+
+//SolveTimed builds the n by n matrix of cost(i, j, t), evaluating every cell at the single instant t so that the costs
+//are consistent with each other, and solves it like Solve
+func SolveTimed(n int64, cost func(i, j int64, t time.Time) float64, t time.Time) ([]Assignment, float64, error) {
+	m := NewMatrix(n)
+	for i := zero64; i < n; i++ {
+		for j := zero64; j < n; j++ {
+			m.SetElement(i, j, cost(i, j, t))
+		}
+	}
+	return Solve(m)
+}
+
+//LazyMatrix is a CostSource that computes each cell with a caller-supplied function the first time it is read and
+//remembers the result. It is not safe for concurrent use.
+type LazyMatrix struct {
+	n        int64
+	cost     func(i, j int64) float64
+	cache    []float64
+	known    []bool
+	computed int64
+}
+
+//NewLazyMatrix returns an n by n LazyMatrix whose cell (i,j) is cost(i, j)
+func NewLazyMatrix(n int64, cost func(i, j int64) float64) *LazyMatrix {
+	return &LazyMatrix{
+		n:     n,
+		cost:  cost,
+		cache: make([]float64, n*n),
+		known: make([]bool, n*n),
+	}
+}
+
+//Size returns N, the number of rows and columns
+func (l *LazyMatrix) Size() int64 {
+	return l.n
+}
+
+//Cost returns the cost at row i, column j, computing it on first use
+func (l *LazyMatrix) Cost(i, j int64) float64 {
+	pos := i*l.n + j
+	if !l.known[pos] {
+		l.cache[pos] = l.cost(i, j)
+		l.known[pos] = true
+		l.computed++
+	}
+	return l.cache[pos]
+}
+
+//ComputedCount returns how many distinct cells have been computed so far
+func (l *LazyMatrix) ComputedCount() int64 {
+	return l.computed
+}
@@ -0,0 +1,122 @@
+package munkres
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestLazyMatrixComputesEachCellOnce(t *testing.T) {
+	calls := 0
+	cost := func(i, j int64) float64 {
+		calls++
+		return float64((i*7 + j*3) % 5)
+	}
+	l := NewLazyMatrix(6, cost)
+	if l.ComputedCount() != 0 {
+		t.Fatalf("%d cells computed before any read", l.ComputedCount())
+	}
+	if l.Cost(2, 3) != 3 || l.Cost(2, 3) != 3 || l.ComputedCount() != 1 || calls != 1 {
+		t.Fatalf("reading one cell twice computed %d cells in %d calls", l.ComputedCount(), calls)
+	}
+	_, total, err := SolveSource(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//a dense solve reads every cell, but each is computed only once
+	if l.ComputedCount() != 36 || calls != 36 {
+		t.Fatalf("dense solve computed %d cells in %d calls, want 36", l.ComputedCount(), calls)
+	}
+	m := NewMatrix(6)
+	for i := int64(0); i < 6; i++ {
+		for j := int64(0); j < 6; j++ {
+			m.SetElement(i, j, float64((i*7+j*3)%5))
+		}
+	}
+	if want := GetMunkresMinScore(m); total != want {
+		t.Fatalf("total %v, want %v", total, want)
+	}
+}
+
+//countingSource is a CostSource over a FloatMatrix that counts how often each cell is read
+type countingSource struct {
+	m     *FloatMatrix
+	reads []int
+}
+
+func (c *countingSource) Size() int64 { return c.m.N }
+
+func (c *countingSource) Cost(i, j int64) float64 {
+	c.reads[i*c.m.N+j]++
+	return c.m.GetElement(i, j)
+}
+
+func TestSolveSource(t *testing.T) {
+	r := rand.New(rand.NewSource(155))
+	for trial := 0; trial < 20; trial++ {
+		m := randomMatrix(r, int64(1+r.Intn(7)))
+		src := &countingSource{m: m, reads: make([]int, len(m.A))}
+		result, total, err := SolveSource(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAssignment(t, m, result, total)
+		if want := bruteForceMin(m); total != want {
+			t.Fatalf("trial %d: total %v, want %v", trial, total, want)
+		}
+		//every cell is read into the working matrix once and the chosen ones once more for their reported cost
+		for _, a := range result {
+			src.reads[a.Row*m.N+a.Col]--
+		}
+		for pos, n := range src.reads {
+			if n != 1 {
+				t.Fatalf("trial %d: cell %d read %d times besides its reported cost", trial, pos, n)
+			}
+		}
+	}
+	bad := &countingSource{m: &FloatMatrix{N: 1, A: []float64{math.NaN()}}, reads: make([]int, 1)}
+	if _, _, err := SolveSource(bad); err == nil {
+		t.Fatal("NaN source accepted")
+	}
+}
+
+func TestSolveTimed(t *testing.T) {
+	morning := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	evening := morning.Add(10 * time.Hour)
+	//before noon each row prefers its own column, afterwards the mirrored one
+	cost := func(i, j int64, at time.Time) float64 {
+		if at.Hour() >= 12 {
+			j = 2 - j
+		}
+		if i == j {
+			return 1
+		}
+		return 5
+	}
+	for _, c := range []struct {
+		at   time.Time
+		cols []int64
+	}{{morning, []int64{0, 1, 2}}, {evening, []int64{2, 1, 0}}} {
+		result, total, err := SolveTimed(3, cost, c.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 3 {
+			t.Fatalf("at %v: total = %v, want 3", c.at, total)
+		}
+		for i, a := range result {
+			if a.Col != c.cols[i] {
+				t.Fatalf("at %v: assignment %v, want columns %v", c.at, result, c.cols)
+			}
+		}
+	}
+	seen := make(map[time.Time]bool)
+	SolveTimed(2, func(i, j int64, at time.Time) float64 {
+		seen[at] = true
+		return 0
+	}, evening)
+	if len(seen) != 1 || !seen[evening] {
+		t.Fatalf("cost evaluated at %v, want only %v", seen, evening)
+	}
+}
@@ -0,0 +1,30 @@
+package munkres
+
+//TraceFrame is a snapshot of the solver taken by GetMunkresTrace after one step
+type TraceFrame struct {
+	//Step is the number, 1 through 6, of the step that just ran
+	Step int
+	//Reduced is a copy of the working matrix after the step
+	Reduced *FloatMatrix
+	//Starred holds the flat positions row*N+col of the starred zeros in ascending order
+	Starred []int
+}
+
+//GetMunkresTrace solves m and returns a frame for every step it ran, in order, ending with the step that completed
+//the solve or found it infeasible. Each frame copies the whole matrix, so tracing is only compiled in with the
+//munkres_debug build tag; without it GetMunkresTrace returns nil without solving, keeping the cost out of production
+//builds.
+func GetMunkresTrace(m *FloatMatrix) []TraceFrame {
+	if !traceEnabled {
+		return nil
+	}
+	ctx := newContext(m)
+	var frames []TraceFrame
+	ctx.onStep = func(stp step) {
+		reduced := NewMatrix(ctx.m.N)
+		copy(reduced.A, ctx.m.A)
+		frames = append(frames, TraceFrame{Step: stepNumber(stp), Reduced: reduced, Starred: ctx.starredPositions()})
+	}
+	ctx.run()
+	return frames
+}
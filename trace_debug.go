@@ -0,0 +1,6 @@
+//go:build munkres_debug
+
+package munkres
+
+//traceEnabled makes GetMunkresTrace record a frame after every step
+const traceEnabled = true
@@ -0,0 +1,34 @@
+//go:build munkres_debug
+
+package munkres
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGetMunkresTraceRecordsSteps(t *testing.T) {
+	m := randomMatrix(rand.New(rand.NewSource(9)), 5)
+	frames := GetMunkresTrace(m)
+	if len(frames) == 0 || frames[0].Step != 1 || frames[len(frames)-1].Step != 3 {
+		t.Fatalf("trace of %d frames does not run from step 1 to a final step 3", len(frames))
+	}
+	last := frames[len(frames)-1]
+	if len(last.Starred) != int(m.N) {
+		t.Fatalf("final frame stars %d cells, want %d", len(last.Starred), m.N)
+	}
+	var total float64
+	for _, pos := range last.Starred {
+		total += m.A[pos]
+		if last.Reduced.A[pos] != 0 {
+			t.Fatalf("starred cell %d has reduced cost %v", pos, last.Reduced.A[pos])
+		}
+	}
+	if want := GetMunkresMinScore(m); total != want {
+		t.Fatalf("final frame stars cells totalling %v, want %v", total, want)
+	}
+	frames[0].Reduced.A[0] = -1
+	if frames[1].Reduced.A[0] == -1 {
+		t.Fatal("frames share their reduced matrices")
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !munkres_debug
+
+package munkres
+
+const traceEnabled = false
@@ -0,0 +1,12 @@
+//go:build !munkres_debug
+
+package munkres
+
+import "testing"
+
+func TestGetMunkresTraceDisabled(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, 3, 4}}
+	if frames := GetMunkresTrace(m); frames != nil {
+		t.Fatalf("GetMunkresTrace returned %d frames without the munkres_debug tag", len(frames))
+	}
+}
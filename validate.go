@@ -0,0 +1,84 @@
+package munkres
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+//ErrNotSquare is returned when a matrix's backing slice does not hold exactly N*N elements
+var ErrNotSquare = errors.New("munkres: matrix is not square")
+
+//ErrInfeasible is returned when every complete assignment uses at least one forbidden (+Inf) cell
+var ErrInfeasible = errors.New("munkres: no assignment avoids the forbidden cells")
+
+//ErrDimensionMismatch is returned when an argument's length or size does not match the matrix it is used with
+var ErrDimensionMismatch = errors.New("munkres: dimension mismatch")
+
+//ErrInvalidPermutation is returned when a slice meant to map rows to columns is not a permutation of the columns
+var ErrInvalidPermutation = errors.New("munkres: invalid permutation")
+
+//ErrOutOfRange is returned when a row or column index lies outside the matrix
+var ErrOutOfRange = errors.New("munkres: index out of range")
+
+//errStopped is recorded by the step loop when the context's stop function asks it to give up
+var errStopped = errors.New("munkres: solve stopped before completion")
+
+//CellError describes the first element of a matrix that failed validation
+type CellError struct {
+	Row    int64
+	Col    int64
+	Value  float64
+	Reason string
+}
+
+func (e *CellError) Error() string {
+	return fmt.Sprintf("munkres: element (%d,%d) = %v is %s", e.Row, e.Col, e.Value, e.Reason)
+}
+
+//Validate checks that the matrix is square and that every element is a finite number or +Inf (forbidden)
+func (m *FloatMatrix) Validate() error {
+	if !m.isSquare() {
+		return ErrNotSquare
+	}
+	for idx, v := range m.A {
+		if math.IsNaN(v) || math.IsInf(v, -1) {
+			return &CellError{Row: int64(idx) / m.N, Col: int64(idx) % m.N, Value: v, Reason: "not a valid cost"}
+		}
+	}
+	return nil
+}
+
+//isSquare reports whether A holds exactly N*N elements
+func (m *FloatMatrix) isSquare() bool {
+	return m.N >= 0 && int64(len(m.A)) == m.N*m.N
+}
+
+//ValidateNonNegative runs Validate and additionally rejects negative elements.
+//Negative costs are handled by the solver, but they often point to a sign error in the caller's cost model.
+func (m *FloatMatrix) ValidateNonNegative() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	for idx, v := range m.A {
+		if v < 0 {
+			return &CellError{Row: int64(idx) / m.N, Col: int64(idx) % m.N, Value: v, Reason: "negative"}
+		}
+	}
+	return nil
+}
+
+//IsValidPermutation reports whether perm has length n and holds every index in [0,n) exactly once
+func IsValidPermutation(perm []int64, n int64) bool {
+	if int64(len(perm)) != n {
+		return false
+	}
+	seen := make([]bool, n)
+	for _, j := range perm {
+		if j < 0 || j >= n || seen[j] {
+			return false
+		}
+		seen[j] = true
+	}
+	return true
+}
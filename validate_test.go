@@ -0,0 +1,55 @@
+package munkres
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, math.Inf(1), -3, 4}}
+	if err := m.Validate(); err != nil {
+		t.Fatalf("negative and forbidden cells rejected: %v", err)
+	}
+	if err := (&FloatMatrix{N: 2, A: make([]float64, 3)}).Validate(); !errors.Is(err, ErrNotSquare) {
+		t.Fatalf("short matrix: err = %v, want ErrNotSquare", err)
+	}
+	for _, bad := range []float64{math.NaN(), math.Inf(-1)} {
+		m.A[3] = bad
+		var ce *CellError
+		if err := m.Validate(); !errors.As(err, &ce) || ce.Row != 1 || ce.Col != 1 {
+			t.Fatalf("%v: err = %v, want a CellError at (1,1)", bad, err)
+		}
+	}
+}
+
+func TestValidateNonNegative(t *testing.T) {
+	m := &FloatMatrix{N: 2, A: []float64{1, 2, -3, 4}}
+	var ce *CellError
+	if err := m.ValidateNonNegative(); !errors.As(err, &ce) || ce.Row != 1 || ce.Col != 0 || ce.Value != -3 {
+		t.Fatalf("err = %v, want a CellError at (1,0)", err)
+	}
+	m.A[2] = 3
+	if err := m.ValidateNonNegative(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsValidPermutation(t *testing.T) {
+	for _, c := range []struct {
+		perm []int64
+		n    int64
+		want bool
+	}{
+		{[]int64{2, 0, 1}, 3, true},
+		{[]int64{}, 0, true},
+		{[]int64{0, 0, 1}, 3, false},
+		{[]int64{0, 1, 3}, 3, false},
+		{[]int64{0, -1, 1}, 3, false},
+		{[]int64{0, 1}, 3, false},
+	} {
+		if got := IsValidPermutation(c.perm, c.n); got != c.want {
+			t.Errorf("IsValidPermutation(%v, %d) = %v, want %v", c.perm, c.n, got, c.want)
+		}
+	}
+}